@@ -0,0 +1,157 @@
+package config
+
+import (
+	"go/build"
+	"go/build/constraint"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// knownOS はGOOSとして有効な値だ（go/build/syslist.goと同じ）
+var knownOS = map[string]bool{
+	"aix": true, "android": true, "darwin": true, "dragonfly": true, "freebsd": true,
+	"hurd": true, "illumos": true, "ios": true, "js": true, "linux": true,
+	"nacl": true, "netbsd": true, "openbsd": true, "plan9": true, "solaris": true,
+	"wasip1": true, "windows": true, "zos": true,
+}
+
+// unixOS は"unix"ビルドタグを満たすGOOSだ
+var unixOS = map[string]bool{
+	"aix": true, "android": true, "darwin": true, "dragonfly": true, "freebsd": true,
+	"hurd": true, "illumos": true, "ios": true, "linux": true, "netbsd": true,
+	"openbsd": true, "solaris": true,
+}
+
+// knownArch はGOARCHとして有効な値だ（go/build/syslist.goと同じ）
+var knownArch = map[string]bool{
+	"386": true, "amd64": true, "amd64p32": true, "arm": true, "armbe": true,
+	"arm64": true, "arm64be": true, "loong64": true, "mips": true, "mipsle": true,
+	"mips64": true, "mips64le": true, "mips64p32": true, "mips64p32le": true,
+	"ppc": true, "ppc64": true, "ppc64le": true, "riscv": true, "riscv64": true,
+	"s390": true, "s390x": true, "sparc": true, "sparc64": true, "wasm": true,
+}
+
+// matchesBuild はルールのbuild_tags/goos指定がファイルに当てはまるか確認する
+//
+// goosは「そのOS向けのビルドに含まれるファイル」に一致する（制約のないファイルも含む）。
+// build_tagsは「指定したタグが有効なときにだけビルドされるファイル」に一致する。
+// goosを省略した場合は現在のGOOS（環境変数GOOS）で評価する。
+func (r *Rule) matchesBuild(file File) bool {
+	if len(r.GOOS) == 0 && len(r.BuildTags) == 0 {
+		return true
+	}
+
+	goosList := r.GOOS
+	if len(goosList) == 0 {
+		goosList = []string{build.Default.GOOS}
+	}
+
+	for _, goos := range goosList {
+		if !fileIncluded(file, goos, r.BuildTags) {
+			continue
+		}
+		// タグなしでもビルドされるファイルはタグで限定されていない
+		if len(r.BuildTags) > 0 && fileIncluded(file, goos, nil) {
+			continue
+		}
+		return true
+	}
+
+	return false
+}
+
+// fileIncluded は指定したGOOSとタグでのビルドにファイルが含まれるか確認する
+func fileIncluded(file File, goos string, tags []string) bool {
+	goarch := build.Default.GOARCH
+	if !matchFileName(filepath.Base(file.Path), goos, goarch) {
+		return false
+	}
+	if file.Constraint == nil {
+		return true
+	}
+
+	return file.Constraint.Eval(func(tag string) bool {
+		switch {
+		case tag == goos, tag == goarch, tag == "gc":
+			return true
+		case tag == "unix":
+			return unixOS[goos]
+		case tag == "linux":
+			return goos == "android"
+		case tag == "solaris":
+			return goos == "illumos"
+		case tag == "darwin":
+			return goos == "ios"
+		case tag == "cgo":
+			return build.Default.CgoEnabled
+		}
+		return slices.Contains(tags, tag) || slices.Contains(build.Default.ReleaseTags, tag)
+	})
+}
+
+// matchFileName はファイル名の_GOOS/_GOARCHサフィックスを確認する
+func matchFileName(name, goos, goarch string) bool {
+	name = strings.TrimSuffix(name, ".go")
+	name = strings.TrimSuffix(name, "_test")
+
+	parts := strings.Split(name, "_")
+	// 先頭の要素はサフィックスとみなさない（例: linux.go）
+	if len(parts) < 2 {
+		return true
+	}
+	parts = parts[1:]
+
+	n := len(parts)
+	if n >= 2 && knownOS[parts[n-2]] && knownArch[parts[n-1]] {
+		return matchOS(parts[n-2], goos) && parts[n-1] == goarch
+	}
+	if knownOS[parts[n-1]] {
+		return matchOS(parts[n-1], goos)
+	}
+	if knownArch[parts[n-1]] {
+		return parts[n-1] == goarch
+	}
+	return true
+}
+
+// matchOS はファイル名のOSがGOOSで有効か確認する
+func matchOS(name, goos string) bool {
+	switch {
+	case name == goos:
+		return true
+	case name == "linux":
+		return goos == "android"
+	case name == "solaris":
+		return goos == "illumos"
+	case name == "darwin":
+		return goos == "ios"
+	}
+	return false
+}
+
+// ParseBuildConstraint はファイル先頭のコメント行からビルド制約を読み取る
+// //go:build行があればそれを使い、なければ// +build行をすべてANDで結合する
+func ParseBuildConstraint(lines []string) constraint.Expr {
+	var plus constraint.Expr
+	for _, line := range lines {
+		if constraint.IsGoBuild(line) {
+			expr, err := constraint.Parse(line)
+			if err == nil {
+				return expr
+			}
+		}
+		if constraint.IsPlusBuild(line) {
+			expr, err := constraint.Parse(line)
+			if err != nil {
+				continue
+			}
+			if plus == nil {
+				plus = expr
+			} else {
+				plus = &constraint.AndExpr{X: plus, Y: expr}
+			}
+		}
+	}
+	return plus
+}
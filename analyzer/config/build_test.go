@@ -0,0 +1,102 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/blck-snwmn/dependencylintgo/analyzer/config"
+)
+
+func TestRuleAppliesToBuild(t *testing.T) {
+	tests := []struct {
+		name  string
+		rule  config.Rule
+		path  string
+		lines []string
+		want  bool
+	}{
+		{
+			name: "goos指定で制約のないファイル",
+			rule: config.Rule{Path: []string{"**.go"}, GOOS: []string{"windows"}},
+			path: "pkg/file.go",
+			want: true,
+		},
+		{
+			name: "goos指定でファイル名のOSが一致",
+			rule: config.Rule{Path: []string{"**.go"}, GOOS: []string{"windows"}},
+			path: "pkg/file_windows.go",
+			want: true,
+		},
+		{
+			name: "goos指定でファイル名のOSが不一致",
+			rule: config.Rule{Path: []string{"**.go"}, GOOS: []string{"windows"}},
+			path: "pkg/file_linux.go",
+			want: false,
+		},
+		{
+			name: "goos指定でテストファイルのOSサフィックス",
+			rule: config.Rule{Path: []string{"**.go"}, GOOS: []string{"linux"}},
+			path: "pkg/file_windows_test.go",
+			want: false,
+		},
+		{
+			name:  "goos指定で//go:buildが一致",
+			rule:  config.Rule{Path: []string{"**.go"}, GOOS: []string{"linux"}},
+			path:  "pkg/file.go",
+			lines: []string{"//go:build unix"},
+			want:  true,
+		},
+		{
+			name:  "goos指定で//go:buildが不一致",
+			rule:  config.Rule{Path: []string{"**.go"}, GOOS: []string{"windows"}},
+			path:  "pkg/file.go",
+			lines: []string{"//go:build unix"},
+			want:  false,
+		},
+		{
+			name:  "build_tags指定でタグ付きファイル",
+			rule:  config.Rule{Path: []string{"**.go"}, BuildTags: []string{"integration"}},
+			path:  "pkg/file_test.go",
+			lines: []string{"//go:build integration"},
+			want:  true,
+		},
+		{
+			name: "build_tags指定でタグなしファイル",
+			rule: config.Rule{Path: []string{"**.go"}, BuildTags: []string{"integration"}},
+			path: "pkg/file_test.go",
+			want: false,
+		},
+		{
+			name:  "build_tags指定で否定タグのファイル",
+			rule:  config.Rule{Path: []string{"**.go"}, BuildTags: []string{"integration"}},
+			path:  "pkg/file_test.go",
+			lines: []string{"//go:build !integration"},
+			want:  false,
+		},
+		{
+			name:  "build_tagsとgoosの組み合わせ",
+			rule:  config.Rule{Path: []string{"**.go"}, GOOS: []string{"linux"}, BuildTags: []string{"integration"}},
+			path:  "pkg/file.go",
+			lines: []string{"//go:build integration && linux"},
+			want:  true,
+		},
+		{
+			name:  "// +build行",
+			rule:  config.Rule{Path: []string{"**.go"}, GOOS: []string{"windows"}},
+			path:  "pkg/file.go",
+			lines: []string{"// +build linux darwin"},
+			want:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := config.File{
+				Path:       tt.path,
+				Constraint: config.ParseBuildConstraint(tt.lines),
+			}
+			if got := tt.rule.AppliesTo(file); got != tt.want {
+				t.Errorf("AppliesTo() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,44 +1,309 @@
 package config
 
 import (
+	"fmt"
+	"go/build/constraint"
 	"os"
 	"path/filepath"
-
-	"gopkg.in/yaml.v3"
 )
 
 // Config は設定ファイルの構造体だ
 type Config struct {
-	Rules []Rule `yaml:"rules"`
+	Version int `yaml:"version,omitempty"` // 設定ファイルの形式のバージョン。省略時は1
+
+	Rules  []Rule  `yaml:"rules,omitempty"`
+	Groups []Group `yaml:"groups,omitempty"`
+
+	Generated string `yaml:"generated,omitempty"` // 生成コードの扱いの既定値（skip|check|only）。省略時はcheck
+
+	Include []string `yaml:"include,omitempty"` // 取り込む設定ファイル。このファイルのルールより先に評価される
+	Extends []string `yaml:"extends,omitempty"` // 継承する設定ファイル。このファイルのルールの後に評価される
+
+	Root bool `yaml:"root,omitempty"` // trueなら親ディレクトリの設定ファイルを探さない（階層的な探索で使う）
+
+	Metrics *Metrics `yaml:"metrics,omitempty"` // llinter metricsで報告する結合度の閾値
+
+	Modules *Modules `yaml:"modules,omitempty"` // go.modのrequire/replace/toolに対するルール
+
+	Licenses *Licenses `yaml:"licenses,omitempty"` // importした外部モジュールのライセンスのルール
+
+	Deprecate []Deprecation `yaml:"deprecate,omitempty"` // 期限付きで非推奨にするimport。すべてのファイルに適用される
+
+	Exceptions []Exception `yaml:"exceptions,omitempty"` // 期限付きでルールの違反を許可する例外
+}
+
+// Exception は期限付きでルールの違反（deny）を許可する例外だ
+// 期限を過ぎると元の違反に加えて、例外が期限切れであることを報告する
+type Exception struct {
+	Path     []string `yaml:"path,omitempty"`     // 適用するファイルパスパターン
+	Packages []string `yaml:"packages,omitempty"` // 適用するパッケージのimportパスパターン
+	Import   string   `yaml:"import,omitempty"`   // 許可するimportパスのパターン
+
+	Owner   string `yaml:"owner,omitempty"`   // 例外に責任を持つ人やチーム
+	Ticket  string `yaml:"ticket,omitempty"`  // 例外を解消するためのチケットのID
+	Expires Date   `yaml:"expires,omitempty"` // 例外が有効な最後の日（例: 2026-12-31）
+}
+
+// Deprecation は期限付きで非推奨にするimportだ
+// 期限の日までは警告として報告し、期限を過ぎるとエラーとして報告する
+type Deprecation struct {
+	Import      string `yaml:"import,omitempty"`       // 非推奨にするimportパスのパターン
+	Until       Date   `yaml:"until,omitempty"`        // 警告にとどめる最後の日（例: 2026-12-31）
+	ReplaceWith string `yaml:"replace_with,omitempty"` // 代わりに使うimportパス
+}
+
+// Licenses は依存モジュールで使ってよいライセンスのルールだ
+// ライセンスはSPDX識別子（例: MIT, Apache-2.0）で指定し、ワイルドカード（例: GPL-*）も使える
+type Licenses struct {
+	Allow []string `yaml:"allow,omitempty"` // 許可するライセンス。空ならdenyに一致しないものをすべて許可する
+	Deny  []string `yaml:"deny,omitempty"`  // 禁止するライセンス（allowよりも優先される）
+
+	AllowUnknown bool `yaml:"allow_unknown,omitempty"` // ライセンスを判別できないモジュールを許可する
+}
+
+// Modules はgo.modで使ってよいモジュールのルールだ
+type Modules struct {
+	Deny  []string `yaml:"deny,omitempty"`  // 禁止するモジュールパスのパターン
+	Allow []string `yaml:"allow,omitempty"` // 許可するモジュールパスのパターン（denyよりも優先される）
+
+	DenyLocalReplace bool `yaml:"deny_local_replace,omitempty"` // ローカルのディレクトリへのreplaceを禁止する
+
+	BannedVersions map[string][]string `yaml:"banned_versions,omitempty"` // モジュールパスのパターンごとに禁止するバージョン
+	Versions       map[string]string   `yaml:"versions,omitempty"`        // モジュールパスのパターンごとのバージョン制約（例: ">= v1.8.0, < v2"）
+}
+
+// Metrics はパッケージやグループの結合度の閾値だ。0の項目はチェックしない
+type Metrics struct {
+	MaxAfferent    int     `yaml:"max_afferent,omitempty"`    // 求心性結合（依存されているパッケージ数）の上限
+	MaxEfferent    int     `yaml:"max_efferent,omitempty"`    // 遠心性結合（依存しているパッケージ数）の上限
+	MaxInstability float64 `yaml:"max_instability,omitempty"` // 不安定度（Ce/(Ca+Ce)）の上限
+	MaxThirdParty  int     `yaml:"max_third_party,omitempty"` // importしている外部モジュールのパッケージ数の上限
 }
 
 // Rule はimportルールを定義するだ
 type Rule struct {
-	Path  []string `yaml:"path"`  // 適用するファイルパスパターン
-	Deny  []string `yaml:"deny"`  // 禁止するimportパターン
-	Allow []string `yaml:"allow"` // 許可するimportパターン（denyよりも優先される）
+	Path  []string `yaml:"path,omitempty"`  // 適用するファイルパスパターン
+	Deny  []string `yaml:"deny,omitempty"`  // 禁止するimportパターン
+	Allow []string `yaml:"allow,omitempty"` // 許可するimportパターン（denyよりも優先される）
+
+	DenySymbols []string `yaml:"deny_symbols,omitempty"` // 禁止するシンボル（例: fmt.Println, time.Now）
+
+	RequireAlias      map[string]string `yaml:"require_alias,omitempty"`       // importパスパターンごとに必須の別名
+	DenyDotImports    bool              `yaml:"deny_dot_imports,omitempty"`    // ドットimportを禁止する
+	DenyBlankImports  bool              `yaml:"deny_blank_imports,omitempty"`  // ブランクimportを禁止する
+	AllowBlankImports []string          `yaml:"allow_blank_imports,omitempty"` // ブランクimportを許可するimportパターン
+
+	Tests       string `yaml:"tests,omitempty"`        // テストファイルの扱い（include|exclude|only）。省略時はinclude
+	TestPackage string `yaml:"test_package,omitempty"` // テストファイルのパッケージ種別（internal|external）。省略時は両方
+
+	BuildTags []string `yaml:"build_tags,omitempty"` // 指定したタグが有効なときにだけビルドされるファイルに適用する
+	GOOS      []string `yaml:"goos,omitempty"`       // 指定したOS向けのビルドに含まれるファイルに適用する
+
+	Generated string `yaml:"generated,omitempty"` // 生成コードの扱い（skip|check|only）。省略時はConfig.Generatedに従う
+
+	Severity string `yaml:"severity,omitempty"` // 違反の重大度（error|warning）。省略時はerror
+
+	MaxImports                  int `yaml:"max_imports,omitempty"`                     // ファイルごとのimport数の上限
+	MaxThirdPartyImports        int `yaml:"max_third_party_imports,omitempty"`         // ファイルごとの外部モジュールのimport数の上限
+	MaxPackageImports           int `yaml:"max_package_imports,omitempty"`             // パッケージごとの異なるimport数の上限
+	MaxPackageThirdPartyImports int `yaml:"max_package_third_party_imports,omitempty"` // パッケージごとの異なる外部モジュールのimport数の上限
+
+	level int // ディレクトリごとの設定ファイルをまとめたときの、ルールを書いた設定ファイルの位置。0が最も下位のディレクトリ
+}
+
+// テストファイルの扱い
+const (
+	TestsInclude = "include" // テストファイルにも適用する
+	TestsExclude = "exclude" // テストファイルには適用しない
+	TestsOnly    = "only"    // テストファイルにだけ適用する
+)
+
+// 生成コードの扱い
+const (
+	GeneratedSkip  = "skip"  // 生成コードには適用しない
+	GeneratedCheck = "check" // 生成コードにも適用する
+	GeneratedOnly  = "only"  // 生成コードにだけ適用する
+)
+
+// テストファイルのパッケージ種別
+const (
+	TestPackageInternal = "internal" // テスト対象と同じパッケージ（package foo）
+	TestPackageExternal = "external" // 外部テストパッケージ（package foo_test）
+)
+
+// 違反の重大度
+const (
+	SeverityError   = "error"   // エラーとして報告する
+	SeverityWarning = "warning" // 警告として報告する
+)
+
+// File はルールの適用判定に使うファイルの情報だ
+type File struct {
+	Path         string // ルールのマッチングに使うファイルパス
+	IsTest       bool   // _test.goファイルか
+	ExternalTest bool   // 外部テストパッケージ（package foo_test）のファイルか
+
+	Constraint constraint.Expr // ファイルのビルド制約（//go:build）。なければnil
+	Generated  bool            // 生成コード（"Code generated ... DO NOT EDIT."）か
+}
+
+// Group はパッケージをまとめたグループを定義するだ
+type Group struct {
+	Name     string   `yaml:"name,omitempty"`     // グループ名
+	Packages []string `yaml:"packages,omitempty"` // グループに含めるパッケージのimportパスパターン
 }
 
 // LoadConfig は設定ファイルを読み込むだ
+// 相対パスの場合はResolveConfigPathで作業ディレクトリから遡って探す
 func LoadConfig(configPath string) (*Config, error) {
-	// 指定されたパスが相対パスなら絶対パスに変換
-	if !filepath.IsAbs(configPath) {
-		cwd, err := os.Getwd()
-		if err != nil {
-			return nil, err
-		}
-		configPath = filepath.Join(cwd, configPath)
+	configPath, err := ResolveConfigPath(configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return loadConfig(configPath, nil)
+}
+
+// applyDefaults は設定全体の既定値を、指定のないルールに反映する
+// Rule.AppliesToのようにルール単体で判定しても、RuleSetと同じ結果になるようにする
+func applyDefaults(config *Config) {
+	for i := range config.Rules {
+		config.Rules[i].applyDefaults(config)
 	}
+}
 
+// applyDefaults は設定全体の既定値をルールに反映する
+// generatedを省略したルールはトップレベルのgeneratedに従い、それも省略されていればcheckとする
+func (r *Rule) applyDefaults(config *Config) {
+	if r.Generated == "" {
+		r.Generated = config.Generated
+	}
+	if r.Generated == "" {
+		r.Generated = GeneratedCheck
+	}
+}
+
+// readConfigFile は1つの設定ファイルを読み込んで検証する（extends/includeは解決しない）
+func readConfigFile(configPath string) (*Config, error) {
 	data, err := os.ReadFile(configPath)
 	if err != nil {
 		return nil, err
 	}
 
 	var config Config
-	if err := yaml.Unmarshal(data, &config); err != nil {
-		return nil, err
+	if err := decodeConfig(configPath, data, &config); err != nil {
+		return nil, fmt.Errorf("%s: %w", configPath, err)
+	}
+
+	if err := expandVariables(&config, filepath.Dir(configPath)); err != nil {
+		return nil, fmt.Errorf("%s: %w", configPath, err)
+	}
+
+	if err := validate(&config); err != nil {
+		return nil, fmt.Errorf("%s: %w", configPath, err)
 	}
 
 	return &config, nil
 }
+
+// validate は設定値が正しいか確認する
+func validate(config *Config) error {
+	if !isValidGenerated(config.Generated) {
+		return fmt.Errorf("invalid generated value %q (must be skip, check or only)", config.Generated)
+	}
+
+	if m := config.Metrics; m != nil {
+		if m.MaxAfferent < 0 || m.MaxEfferent < 0 || m.MaxThirdParty < 0 {
+			return fmt.Errorf("metrics: thresholds must not be negative")
+		}
+		if m.MaxInstability < 0 || m.MaxInstability > 1 {
+			return fmt.Errorf("metrics: invalid max_instability %v (must be between 0 and 1)", m.MaxInstability)
+		}
+	}
+
+	if m := config.Modules; m != nil {
+		for pattern, constraint := range m.Versions {
+			if _, err := ParseVersionConstraint(constraint); err != nil {
+				return fmt.Errorf("modules.versions[%q]: %w", pattern, err)
+			}
+		}
+	}
+
+	if l := config.Licenses; l != nil {
+		for _, pattern := range append(append([]string(nil), l.Allow...), l.Deny...) {
+			if _, err := filepath.Match(pattern, ""); err != nil {
+				return fmt.Errorf("licenses: invalid pattern %q: %w", pattern, err)
+			}
+		}
+	}
+
+	for i, d := range config.Deprecate {
+		if d.Import == "" {
+			return fmt.Errorf("deprecate[%d]: import is required", i)
+		}
+		if d.Until.IsZero() {
+			return fmt.Errorf("deprecate[%d]: until is required", i)
+		}
+	}
+
+	for i, e := range config.Exceptions {
+		if len(e.Path) == 0 && len(e.Packages) == 0 {
+			return fmt.Errorf("exceptions[%d]: path or packages is required", i)
+		}
+		if e.Import == "" {
+			return fmt.Errorf("exceptions[%d]: import is required", i)
+		}
+		if e.Owner == "" {
+			return fmt.Errorf("exceptions[%d]: owner is required", i)
+		}
+		if e.Ticket == "" {
+			return fmt.Errorf("exceptions[%d]: ticket is required", i)
+		}
+		if e.Expires.IsZero() {
+			return fmt.Errorf("exceptions[%d]: expires is required", i)
+		}
+	}
+
+	for i, rule := range config.Rules {
+		switch rule.Tests {
+		case "", TestsInclude, TestsExclude, TestsOnly:
+		default:
+			return fmt.Errorf("rules[%d]: invalid tests value %q (must be include, exclude or only)", i, rule.Tests)
+		}
+
+		switch rule.TestPackage {
+		case "", TestPackageInternal, TestPackageExternal:
+		default:
+			return fmt.Errorf("rules[%d]: invalid test_package value %q (must be internal or external)", i, rule.TestPackage)
+		}
+
+		if !isValidGenerated(rule.Generated) {
+			return fmt.Errorf("rules[%d]: invalid generated value %q (must be skip, check or only)", i, rule.Generated)
+		}
+
+		switch rule.Severity {
+		case "", SeverityError, SeverityWarning:
+		default:
+			return fmt.Errorf("rules[%d]: invalid severity value %q (must be error or warning)", i, rule.Severity)
+		}
+
+		if rule.MaxImports < 0 || rule.MaxThirdPartyImports < 0 || rule.MaxPackageImports < 0 || rule.MaxPackageThirdPartyImports < 0 {
+			return fmt.Errorf("rules[%d]: import limits must not be negative", i)
+		}
+
+		for _, goos := range rule.GOOS {
+			if !knownOS[goos] {
+				return fmt.Errorf("rules[%d]: unknown goos %q", i, goos)
+			}
+		}
+	}
+	return nil
+}
+
+func isValidGenerated(value string) bool {
+	switch value {
+	case "", GeneratedSkip, GeneratedCheck, GeneratedOnly:
+		return true
+	}
+	return false
+}
@@ -3,6 +3,8 @@ package config_test
 import (
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/blck-snwmn/dependencylintgo/analyzer/config"
@@ -170,3 +172,299 @@ rules:
 		t.Error("Recursive wildcard pattern should match github.com/forbidden/sub/pkg")
 	}
 }
+
+func TestLoadConfigInvalidTests(t *testing.T) {
+	tempDir := t.TempDir()
+
+	// testsに不正な値を指定した設定ファイルを作成
+	configContent := `
+rules:
+  - path: ["src/**/*.go"]
+    tests: sometimes
+    deny:
+      - "fmt"
+`
+	configPath := filepath.Join(tempDir, ".llinter.yaml")
+	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+		t.Fatalf("Failed to write test config file: %v", err)
+	}
+
+	if _, err := config.LoadConfig(configPath); err == nil {
+		t.Error("Expected error for invalid tests value, got nil")
+	}
+}
+
+func TestLoadConfigInvalidSeverity(t *testing.T) {
+	tempDir := t.TempDir()
+
+	// severityに不正な値を指定した設定ファイルを作成
+	configContent := `
+rules:
+  - path: ["src/**/*.go"]
+    severity: fatal
+    deny:
+      - "fmt"
+`
+	configPath := filepath.Join(tempDir, ".llinter.yaml")
+	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+		t.Fatalf("Failed to write test config file: %v", err)
+	}
+
+	if _, err := config.LoadConfig(configPath); err == nil {
+		t.Error("Expected error for invalid severity value, got nil")
+	}
+}
+
+func TestLoadConfigMetrics(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    *config.Metrics
+		wantErr bool
+	}{
+		{
+			name:    "閾値",
+			content: "metrics:\n  max_efferent: 20\n  max_instability: 0.8\n",
+			want:    &config.Metrics{MaxEfferent: 20, MaxInstability: 0.8},
+		},
+		{
+			name:    "不正な不安定度",
+			content: "metrics:\n  max_instability: 1.5\n",
+			wantErr: true,
+		},
+		{
+			name:    "負の閾値",
+			content: "metrics:\n  max_afferent: -1\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), ".llinter.yaml")
+			if err := os.WriteFile(configPath, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("Failed to write test config file: %v", err)
+			}
+
+			cfg, err := config.LoadConfig(configPath)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("Expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Failed to load config: %v", err)
+			}
+			if !reflect.DeepEqual(cfg.Metrics, tt.want) {
+				t.Errorf("Expected metrics %+v, got %+v", tt.want, cfg.Metrics)
+			}
+		})
+	}
+}
+
+func TestLoadConfigDeprecate(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		want    string // 「import=until」
+		wantErr bool
+	}{
+		{
+			name:    "YAMLの日付",
+			file:    ".llinter.yaml",
+			content: "deprecate:\n  - import: \"github.com/old/log\"\n    until: 2026-12-31\n    replace_with: \"log/slog\"\n",
+			want:    "github.com/old/log=2026-12-31",
+		},
+		{
+			name:    "JSONの文字列",
+			file:    ".llinter.json",
+			content: `{"deprecate": [{"import": "github.com/old/log", "until": "2026-12-31"}]}`,
+			want:    "github.com/old/log=2026-12-31",
+		},
+		{
+			name:    "TOMLの日付",
+			file:    ".llinter.toml",
+			content: "[[deprecate]]\nimport = \"github.com/old/log\"\nuntil = 2026-12-31\n",
+			want:    "github.com/old/log=2026-12-31",
+		},
+		{
+			name:    "不正な日付",
+			file:    ".llinter.yaml",
+			content: "deprecate:\n  - import: \"github.com/old/log\"\n    until: 2026-13-01\n",
+			wantErr: true,
+		},
+		{
+			name:    "期限がない",
+			file:    ".llinter.yaml",
+			content: "deprecate:\n  - import: \"github.com/old/log\"\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), tt.file)
+			if err := os.WriteFile(configPath, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("Failed to write test config file: %v", err)
+			}
+
+			cfg, err := config.LoadConfig(configPath)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("Expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Failed to load config: %v", err)
+			}
+			if len(cfg.Deprecate) != 1 {
+				t.Fatalf("Expected 1 deprecation, got %+v", cfg.Deprecate)
+			}
+			if got := cfg.Deprecate[0].Import + "=" + cfg.Deprecate[0].Until.String(); got != tt.want {
+				t.Errorf("Expected deprecation %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestLoadConfigExceptions(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{
+			name:    "必須の項目がそろっている",
+			content: "exceptions:\n  - path: [\"internal/**\"]\n    import: \"os\"\n    owner: \"@team-a\"\n    ticket: \"APP-1\"\n    expires: 2026-12-31\n",
+		},
+		{
+			name:    "担当者がない",
+			content: "exceptions:\n  - path: [\"internal/**\"]\n    import: \"os\"\n    ticket: \"APP-1\"\n    expires: 2026-12-31\n",
+			wantErr: "exceptions[0]: owner is required",
+		},
+		{
+			name:    "チケットがない",
+			content: "exceptions:\n  - path: [\"internal/**\"]\n    import: \"os\"\n    owner: \"@team-a\"\n    expires: 2026-12-31\n",
+			wantErr: "exceptions[0]: ticket is required",
+		},
+		{
+			name:    "期限がない",
+			content: "exceptions:\n  - path: [\"internal/**\"]\n    import: \"os\"\n    owner: \"@team-a\"\n    ticket: \"APP-1\"\n",
+			wantErr: "exceptions[0]: expires is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), ".llinter.yaml")
+			if err := os.WriteFile(configPath, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("Failed to write test config file: %v", err)
+			}
+
+			_, err := config.LoadConfig(configPath)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Failed to load config: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestLoadConfigFormats(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+	}{
+		{
+			name: "YAML",
+			file: "config.yml",
+			content: `
+generated: skip
+rules:
+  - path: ["src/**/*.go"]
+    deny: ["fmt"]
+    deny_dot_imports: true
+    require_alias:
+      "github.com/acme/v2/client": "clientv2"
+`,
+		},
+		{
+			name: "JSON",
+			file: "config.json",
+			content: `{
+  "generated": "skip",
+  "rules": [
+    {
+      "path": ["src/**/*.go"],
+      "deny": ["fmt"],
+      "deny_dot_imports": true,
+      "require_alias": {"github.com/acme/v2/client": "clientv2"}
+    }
+  ]
+}`,
+		},
+		{
+			name: "TOML",
+			file: "config.toml",
+			content: `
+generated = "skip"
+
+[[rules]]
+path = ["src/**/*.go"]
+deny = ["fmt"]
+deny_dot_imports = true
+
+[rules.require_alias]
+"github.com/acme/v2/client" = "clientv2"
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), tt.file)
+			if err := os.WriteFile(configPath, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("Failed to write test config file: %v", err)
+			}
+
+			cfg, err := config.LoadConfig(configPath)
+			if err != nil {
+				t.Fatalf("Failed to load config: %v", err)
+			}
+
+			if cfg.Generated != config.GeneratedSkip {
+				t.Errorf("Unexpected generated: %q", cfg.Generated)
+			}
+			if len(cfg.Rules) != 1 {
+				t.Fatalf("Expected 1 rule, got %d", len(cfg.Rules))
+			}
+			rule := cfg.Rules[0]
+			if rule.Path[0] != "src/**/*.go" || rule.Deny[0] != "fmt" || !rule.DenyDotImports {
+				t.Errorf("Unexpected rule: %+v", rule)
+			}
+			if rule.RequireAlias["github.com/acme/v2/client"] != "clientv2" {
+				t.Errorf("Unexpected require_alias: %v", rule.RequireAlias)
+			}
+		})
+	}
+}
+
+func TestLoadConfigInvalidJSON(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(configPath, []byte(`{"rules": [}`), 0644); err != nil {
+		t.Fatalf("Failed to write test config file: %v", err)
+	}
+
+	if _, err := config.LoadConfig(configPath); err == nil {
+		t.Error("Expected error for invalid JSON, got nil")
+	}
+}
@@ -0,0 +1,88 @@
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// dateLayout は設定ファイルに書く日付の形式だ
+const dateLayout = "2006-01-02"
+
+// Date は時刻を持たない日付だ。設定ファイルでは2026-12-31の形式で書く
+type Date struct {
+	t time.Time
+}
+
+// ParseDate は2006-01-02の形式の日付を解析する
+// TOMLの日付などYAMLのタイムスタンプを経由したものも受け付け、日付の部分だけを使う
+func ParseDate(s string) (Date, error) {
+	if t, err := time.Parse(dateLayout, s); err == nil {
+		return Date{t: t}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return NewDate(t), nil
+	}
+	return Date{}, fmt.Errorf("invalid date %q (must be YYYY-MM-DD)", s)
+}
+
+// NewDate は時刻のタイムゾーンでの日付を返す
+func NewDate(t time.Time) Date {
+	return Date{t: time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)}
+}
+
+// Today は現在の日付を返す
+func Today() Date {
+	return NewDate(time.Now())
+}
+
+// IsZero は日付が設定されていないか確認する
+func (d Date) IsZero() bool {
+	return d.t.IsZero()
+}
+
+// Before はdがuより前の日付か確認する
+func (d Date) Before(u Date) bool {
+	return d.t.Before(u.t)
+}
+
+// After はdがuより後の日付か確認する
+func (d Date) After(u Date) bool {
+	return d.t.After(u.t)
+}
+
+// DaysUntil はdからuまでの日数を返す。uが過去なら負になる
+func (d Date) DaysUntil(u Date) int {
+	return int(u.t.Sub(d.t).Hours() / 24)
+}
+
+func (d Date) String() string {
+	if d.IsZero() {
+		return ""
+	}
+	return d.t.Format(dateLayout)
+}
+
+// UnmarshalYAML は日付をYAMLのスカラーから読み込む
+func (d *Date) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind != yaml.ScalarNode {
+		return fmt.Errorf("line %d: date must be a string", value.Line)
+	}
+	parsed, err := ParseDate(value.Value)
+	if err != nil {
+		return fmt.Errorf("line %d: %w", value.Line, err)
+	}
+	*d = parsed
+	return nil
+}
+
+// MarshalYAML は日付をYAMLに2006-01-02の形式で書き出す
+func (d Date) MarshalYAML() (any, error) {
+	return d.String(), nil
+}
+
+// MarshalJSON は日付をJSONに2006-01-02の形式で書き出す
+func (d Date) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + d.String() + `"`), nil
+}
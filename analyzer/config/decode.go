@@ -0,0 +1,76 @@
+package config
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// parsers は拡張子ごとの設定ファイルのパーサだ
+// どの形式もYAMLのノードに変換してから、バージョンごとのデコーダで読み込む
+// 一致する拡張子がなければYAMLとして読み込む
+var parsers = map[string]func(data []byte) (*yaml.Node, error){
+	".yaml": parseYAML,
+	".yml":  parseYAML,
+	".json": parseJSON,
+	".toml": parseTOML,
+}
+
+// decodeConfig は拡張子に応じたパーサとバージョンに応じたデコーダで設定ファイルを読み込む
+func decodeConfig(configPath string, data []byte, config *Config) error {
+	parse, ok := parsers[strings.ToLower(filepath.Ext(configPath))]
+	if !ok {
+		parse = parseYAML
+	}
+
+	node, err := parse(data)
+	if err != nil {
+		return err
+	}
+	// 空のファイル
+	if node == nil {
+		return nil
+	}
+
+	return decodeVersioned(node, config)
+}
+
+func parseYAML(data []byte) (*yaml.Node, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	if len(doc.Content) == 0 {
+		return nil, nil
+	}
+	return doc.Content[0], nil
+}
+
+func parseJSON(data []byte) (*yaml.Node, error) {
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, err
+	}
+	return toNode(value)
+}
+
+func parseTOML(data []byte) (*yaml.Node, error) {
+	var value map[string]any
+	if err := toml.Unmarshal(data, &value); err != nil {
+		return nil, err
+	}
+	return toNode(value)
+}
+
+// toNode は汎用の値をYAMLのノードに変換する
+// フィールド名の対応をyamlタグに一本化するため、YAMLを経由して読み込む
+func toNode(value any) (*yaml.Node, error) {
+	data, err := yaml.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	return parseYAML(data)
+}
@@ -0,0 +1,226 @@
+package config
+
+import (
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+)
+
+// DefaultConfigName は設定ファイルの既定の名前だ
+const DefaultConfigName = ".llinter.yaml"
+
+// defaultConfigNames は既定の名前を指定したときに探す設定ファイルの名前だ
+var defaultConfigNames = []string{DefaultConfigName, ".llinter.yml", ".llinter.json", ".llinter.toml"}
+
+// ResolveConfigPath は設定ファイルのパスを絶対パスにする
+//
+// 相対パスの場合は作業ディレクトリから親ディレクトリへ遡り、最初に見つかったファイルを使う
+// 遡るのはモジュールルート（go.mod）、ワークスペースのルート（go.work）かVCSのルート（.git）までとする
+// 既定の名前（.llinter.yaml）の場合は .llinter.yml、.llinter.json、.llinter.toml も探す
+// 見つからない場合は作業ディレクトリからのパスとfs.ErrNotExistを返す
+func ResolveConfigPath(configPath string) (string, error) {
+	if filepath.IsAbs(configPath) {
+		return configPath, nil
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+
+	for dir := cwd; ; {
+		for _, name := range configNames(configPath) {
+			candidate := filepath.Join(dir, name)
+			if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+				return candidate, nil
+			}
+		}
+
+		if isProjectRoot(dir) {
+			break
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+	}
+
+	notFound := filepath.Join(cwd, configPath)
+	return notFound, &fs.PathError{Op: "open", Path: notFound, Err: fs.ErrNotExist}
+}
+
+// ResolveConfigPathFor はdirのパッケージに使う設定ファイルのパスを絶対パスにする
+//
+// go.workのワークスペースでは、モジュールごとにそのルートへ設定ファイルを置ける
+// パッケージを含むモジュールのルートに設定ファイルがあればそれを使い、なければResolveConfigPathと同じく探す
+func ResolveConfigPathFor(configPath, dir string) (string, error) {
+	if filepath.IsAbs(configPath) {
+		return configPath, nil
+	}
+
+	if moduleRoot, _, err := FindModule(dir); err == nil {
+		ws, err := moduleWorkspace(moduleRoot)
+		if err != nil {
+			return "", err
+		}
+		if ws != nil {
+			for _, name := range configNames(configPath) {
+				candidate := filepath.Join(moduleRoot, name)
+				if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+					return candidate, nil
+				}
+			}
+		}
+	}
+
+	return ResolveConfigPath(configPath)
+}
+
+// configNames は設定ファイルのパスとして探す名前を返す
+func configNames(configPath string) []string {
+	if configPath == DefaultConfigName {
+		return defaultConfigNames
+	}
+	return []string{configPath}
+}
+
+// isProjectRoot はディレクトリがモジュールルート、ワークスペースのルートかVCSのルートか確認する
+func isProjectRoot(dir string) bool {
+	for _, marker := range []string{"go.mod", "go.work", ".git"} {
+		if _, err := os.Stat(filepath.Join(dir, marker)); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// Discover はdirからモジュールルート（go.modのあるディレクトリ）まで遡って各ディレクトリの設定ファイルを探し、
+// .gitignoreのように下位のディレクトリの設定ほど優先されるようにまとめる
+// root: trueの設定ファイルが見つかったらそれより上は探さない
+// モジュールがgo.workのワークスペースで使われていれば、go.workのあるディレクトリまで遡る
+//
+// ルールのpathパターンは各設定ファイルのディレクトリからの相対パスとして書かれているものとし、
+// 返り値のルートディレクトリからの相対パスに書き換える
+// 設定ファイルが1つも見つからない場合はnilを返す
+//
+// 同じファイルに一致するルールが複数の設定ファイルにあると、下位のディレクトリのルールが適用され、
+// 上位のディレクトリのルールのdenyも引き継がれる（RuleSet.MatchInherited）
+func Discover(dir string) (*Config, string, error) {
+	config, root, _, err := DiscoverFiles(dir)
+	return config, root, err
+}
+
+// DiscoverFiles はDiscoverと同じく設定をまとめ、まとめた設定ファイルのパスも返す
+// パスは優先される（下位のディレクトリの）順に並ぶ
+func DiscoverFiles(dir string) (*Config, string, []string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	type found struct {
+		config *Config
+		path   string
+		dir    string
+		shared bool // ワークスペースの設定で、モジュールルートより上にある
+	}
+	var configs []found
+
+	var (
+		root      = dir
+		workspace *Workspace // モジュールルートより上を探しているときのワークスペース
+	)
+	for current := dir; ; {
+		if workspace == nil {
+			root = current
+		}
+
+		var cfg *Config
+		for _, name := range defaultConfigNames {
+			path := filepath.Join(current, name)
+			c, err := LoadConfig(path)
+			if err == nil {
+				cfg = c
+				configs = append(configs, found{config: cfg, path: path, dir: current, shared: workspace != nil})
+				break
+			}
+			if !IsNotFound(err) {
+				return nil, "", nil, err
+			}
+		}
+
+		if cfg != nil && cfg.Root {
+			break
+		}
+		if workspace != nil && current == workspace.Dir {
+			break
+		}
+		if workspace == nil {
+			if _, err := os.Stat(filepath.Join(current, "go.mod")); err == nil {
+				// go.workのワークスペースのモジュールなら、go.workのあるディレクトリまで遡る
+				ws, err := moduleWorkspace(current)
+				if err != nil {
+					return nil, "", nil, err
+				}
+				if ws == nil || ws.Dir == current {
+					break
+				}
+				workspace = ws
+			}
+		}
+
+		parent := filepath.Dir(current)
+		if parent == current {
+			break
+		}
+		current = parent
+	}
+
+	if len(configs) == 0 {
+		return nil, root, nil, nil
+	}
+
+	// 下位のディレクトリの設定から順に並んでいる
+	// ワークスペースの設定はすべてのモジュールに共通なので、pathはそのままモジュールルートからの相対とする
+	ordered := make([]*Config, 0, len(configs))
+	paths := make([]string, 0, len(configs))
+	for level, f := range configs {
+		for i := range f.config.Rules {
+			f.config.Rules[i].level = level
+		}
+		if !f.shared {
+			rel, err := filepath.Rel(root, f.dir)
+			if err != nil {
+				return nil, "", nil, err
+			}
+			rebaseRules(f.config, filepath.ToSlash(rel))
+		}
+		ordered = append(ordered, f.config)
+		paths = append(paths, f.path)
+	}
+
+	return mergeConfigs(ordered), root, paths, nil
+}
+
+// rebaseRules はルールと例外のpathパターンの先頭にディレクトリを付け加える
+func rebaseRules(config *Config, dir string) {
+	if dir == "." {
+		return
+	}
+	for i := range config.Rules {
+		rebasePatterns(config.Rules[i].Path, dir)
+	}
+	for i := range config.Exceptions {
+		rebasePatterns(config.Exceptions[i].Path, dir)
+	}
+}
+
+func rebasePatterns(patterns []string, dir string) {
+	for i, pattern := range patterns {
+		if !path.IsAbs(pattern) {
+			patterns[i] = path.Join(dir, pattern)
+		}
+	}
+}
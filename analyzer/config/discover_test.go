@@ -0,0 +1,236 @@
+package config_test
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/blck-snwmn/dependencylintgo/analyzer/config"
+)
+
+func TestDiscover(t *testing.T) {
+	tempDir := t.TempDir()
+	writeFiles(t, tempDir, map[string]string{
+		// go.modより上の設定ファイルは使わない
+		".llinter.yaml": `
+rules:
+  - path: ["**/*.go"]
+    deny: ["outside"]
+`,
+		"repo/go.mod": "module example.com/repo\n",
+		"repo/.llinter.yaml": `
+rules:
+  - path: ["**/*.go"]
+    deny: ["repo"]
+groups:
+  - name: billing
+    packages: ["example.com/repo/billing/**"]
+`,
+		"repo/svc/.llinter.yaml": `
+rules:
+  - path: ["internal/**/*.go"]
+    deny: ["svc"]
+groups:
+  - name: billing
+    packages: ["example.com/repo/svc/billing/**"]
+`,
+		"repo/svc/internal/pkg/.keep": "",
+		"repo/isolated/.llinter.yaml": `
+root: true
+rules:
+  - path: ["*.go"]
+    deny: ["isolated"]
+`,
+		"repo/isolated/pkg/.keep": "",
+	})
+
+	t.Run("モジュールルートまで遡る", func(t *testing.T) {
+		cfg, root, err := config.Discover(filepath.Join(tempDir, "repo/svc/internal/pkg"))
+		if err != nil {
+			t.Fatalf("Failed to discover config: %v", err)
+		}
+		if root != filepath.Join(tempDir, "repo") {
+			t.Errorf("Unexpected root: %s", root)
+		}
+
+		// 下位のディレクトリの設定が先に並び、pathはルートからの相対になる
+		var got []string
+		for _, rule := range cfg.Rules {
+			got = append(got, rule.Path[0]+"="+rule.Deny[0])
+		}
+		want := "svc/internal/**/*.go=svc,**/*.go=repo"
+		if strings.Join(got, ",") != want {
+			t.Errorf("Unexpected rules: got %v, want %s", got, want)
+		}
+
+		// 上位のディレクトリの設定ファイルのルールは引き継がれ、同じ設定ファイルのルールは最初に一致したものだけ
+		rules := config.CompileRules(cfg)
+		if got := rules.MatchInherited(config.File{Path: "svc/internal/pkg/a.go"}); len(got) != 2 || got[0] != 0 || got[1] != 1 {
+			t.Errorf("Unexpected inherited rules: %v", got)
+		}
+		if got := rules.MatchInherited(config.File{Path: "svc/a.go"}); len(got) != 1 || got[0] != 1 {
+			t.Errorf("Unexpected inherited rules: %v", got)
+		}
+
+		if len(cfg.Groups) != 1 || cfg.Groups[0].Packages[0] != "example.com/repo/svc/billing/**" {
+			t.Errorf("Unexpected groups: %v", cfg.Groups)
+		}
+
+		// まとめた設定ファイルは優先される順に並ぶ
+		_, _, paths, err := config.DiscoverFiles(filepath.Join(tempDir, "repo/svc/internal/pkg"))
+		if err != nil {
+			t.Fatalf("Failed to discover config: %v", err)
+		}
+		wantPaths := []string{
+			filepath.Join(tempDir, "repo/svc/.llinter.yaml"),
+			filepath.Join(tempDir, "repo/.llinter.yaml"),
+		}
+		if strings.Join(paths, ",") != strings.Join(wantPaths, ",") {
+			t.Errorf("Unexpected paths: got %v, want %v", paths, wantPaths)
+		}
+	})
+
+	t.Run("root: trueで探索を止める", func(t *testing.T) {
+		cfg, root, err := config.Discover(filepath.Join(tempDir, "repo/isolated/pkg"))
+		if err != nil {
+			t.Fatalf("Failed to discover config: %v", err)
+		}
+		if root != filepath.Join(tempDir, "repo/isolated") {
+			t.Errorf("Unexpected root: %s", root)
+		}
+		if len(cfg.Rules) != 1 || cfg.Rules[0].Deny[0] != "isolated" {
+			t.Errorf("Unexpected rules: %v", cfg.Rules)
+		}
+	})
+
+	t.Run("go.workのあるディレクトリまで遡る", func(t *testing.T) {
+		writeFiles(t, tempDir, map[string]string{
+			"ws/go.work": "go 1.24\n\nuse ./svc\n",
+			"ws/.llinter.yaml": `
+rules:
+  - path: ["internal/**/*.go"]
+    deny: ["workspace"]
+`,
+			"ws/svc/go.mod": "module example.com/svc\n",
+			"ws/svc/api/.llinter.yaml": `
+rules:
+  - path: ["*.go"]
+    deny: ["api"]
+`,
+			"ws/unused/go.mod":    "module example.com/unused\n",
+			"ws/unused/pkg/.keep": "",
+		})
+
+		cfg, root, err := config.Discover(filepath.Join(tempDir, "ws/svc/api"))
+		if err != nil {
+			t.Fatalf("Failed to discover config: %v", err)
+		}
+		if root != filepath.Join(tempDir, "ws/svc") {
+			t.Errorf("Unexpected root: %s", root)
+		}
+
+		// ワークスペースの設定のpathはモジュールルートからの相対のまま
+		var got []string
+		for _, rule := range cfg.Rules {
+			got = append(got, rule.Path[0]+"="+rule.Deny[0])
+		}
+		want := "api/*.go=api,internal/**/*.go=workspace"
+		if strings.Join(got, ",") != want {
+			t.Errorf("Unexpected rules: got %v, want %s", got, want)
+		}
+
+		// useに含まれないモジュールはワークスペースの設定を使わない
+		cfg, _, err = config.Discover(filepath.Join(tempDir, "ws/unused/pkg"))
+		if err != nil || cfg != nil {
+			t.Errorf("Expected no config, got %v, %v", cfg, err)
+		}
+	})
+
+	t.Run("設定ファイルがない", func(t *testing.T) {
+		writeFiles(t, tempDir, map[string]string{"empty/go.mod": "module example.com/empty\n"})
+		cfg, _, err := config.Discover(filepath.Join(tempDir, "empty"))
+		if err != nil || cfg != nil {
+			t.Errorf("Expected no config, got %v, %v", cfg, err)
+		}
+	})
+}
+
+func TestResolveConfigPath(t *testing.T) {
+	tempDir := t.TempDir()
+	writeFiles(t, tempDir, map[string]string{
+		"repo/go.mod":              "module example.com/repo\n",
+		"repo/.llinter.yaml":       "rules: []\n",
+		"repo/pkg/sub/.keep":       "",
+		"json/go.mod":              "module example.com/json\n",
+		"json/.llinter.json":       `{"rules": [{"path": ["*.go"], "deny": ["fmt"]}]}`,
+		"json/pkg/.keep":           "",
+		"outside/.llinter.yaml":    "rules: []\n",
+		"outside/repo/.git/HEAD":   "ref: refs/heads/main\n",
+		"outside/repo/pkg/.keep":   "",
+		"custom/go.mod":            "module example.com/custom\n",
+		"custom/lint/custom.yaml":  "rules: []\n",
+		"custom/lint/nested/.keep": "",
+	})
+
+	tests := []struct {
+		name       string
+		cwd        string
+		configPath string
+		want       string // 空文字は見つからない
+	}{
+		{
+			name:       "サブディレクトリからモジュールルートまで遡る",
+			cwd:        "repo/pkg/sub",
+			configPath: ".llinter.yaml",
+			want:       "repo/.llinter.yaml",
+		},
+		{
+			name:       "既定の名前ならJSONの設定ファイルも探す",
+			cwd:        "json/pkg",
+			configPath: ".llinter.yaml",
+			want:       "json/.llinter.json",
+		},
+		{
+			name:       "VCSのルートより上は探さない",
+			cwd:        "outside/repo/pkg",
+			configPath: ".llinter.yaml",
+			want:       "",
+		},
+		{
+			name:       "既定以外の名前",
+			cwd:        "custom/lint/nested",
+			configPath: "custom.yaml",
+			want:       "custom/lint/custom.yaml",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Chdir(filepath.Join(tempDir, tt.cwd))
+
+			got, err := config.ResolveConfigPath(tt.configPath)
+			if tt.want == "" {
+				if !config.IsNotFound(err) {
+					t.Errorf("Expected not found error, got %q, %v", got, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Failed to resolve config path: %v", err)
+			}
+			if want := filepath.Join(tempDir, tt.want); got != want {
+				t.Errorf("ResolveConfigPath() = %s, want %s", got, want)
+			}
+		})
+	}
+
+	// 見つかったJSONの設定ファイルも読み込める
+	t.Chdir(filepath.Join(tempDir, "json/pkg"))
+	cfg, err := config.LoadConfig(".llinter.yaml")
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	if len(cfg.Rules) != 1 || cfg.Rules[0].Deny[0] != "fmt" {
+		t.Errorf("Unexpected rules: %v", cfg.Rules)
+	}
+}
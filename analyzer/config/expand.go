@@ -0,0 +1,189 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// variablePattern は設定中の${NAME}形式の変数だ
+var variablePattern = regexp.MustCompile(`\$\{([^}]*)\}`)
+
+// variables は設定ファイルの変数を解決する
+//   - ${MODULE}: 設定ファイルを含むモジュールのモジュールパス
+//   - ${MODULE_ROOT}: 設定ファイルを含むモジュールのルートディレクトリ
+//   - ${env:NAME}: 環境変数NAMEの値
+type variables struct {
+	dir string // 設定ファイルのディレクトリ
+
+	// モジュールはgo.modを読む必要があるので、使われたときに一度だけ探す
+	moduleLoaded bool
+	moduleRoot   string
+	modulePath   string
+	moduleErr    error
+}
+
+func (v *variables) lookup(name string) (string, error) {
+	if env, ok := strings.CutPrefix(name, "env:"); ok {
+		value, ok := os.LookupEnv(env)
+		if !ok {
+			return "", fmt.Errorf("environment variable %s is not set", env)
+		}
+		return value, nil
+	}
+
+	switch name {
+	case "MODULE", "MODULE_ROOT":
+		if !v.moduleLoaded {
+			v.moduleRoot, v.modulePath, v.moduleErr = FindModule(v.dir)
+			v.moduleLoaded = true
+		}
+		if v.moduleErr != nil {
+			return "", fmt.Errorf("cannot resolve ${%s}: %w", name, v.moduleErr)
+		}
+		if name == "MODULE" {
+			return v.modulePath, nil
+		}
+		return v.moduleRoot, nil
+	}
+	return "", fmt.Errorf("unknown variable ${%s}", name)
+}
+
+// expand は文字列中の変数を展開する
+func (v *variables) expand(s string) (string, error) {
+	var expandErr error
+	result := variablePattern.ReplaceAllStringFunc(s, func(match string) string {
+		value, err := v.lookup(variablePattern.FindStringSubmatch(match)[1])
+		if err != nil && expandErr == nil {
+			expandErr = err
+		}
+		return value
+	})
+	return result, expandErr
+}
+
+// expandVariables は設定中のパターンに含まれる変数を展開する
+func expandVariables(config *Config, dir string) error {
+	v := &variables{dir: dir}
+
+	expandAll := func(field string, values []string) error {
+		for i, value := range values {
+			expanded, err := v.expand(value)
+			if err != nil {
+				return fmt.Errorf("%s[%d]: %w", field, i, err)
+			}
+			values[i] = expanded
+		}
+		return nil
+	}
+
+	for i := range config.Rules {
+		rule := &config.Rules[i]
+		fields := []struct {
+			name   string
+			values []string
+		}{
+			{"path", rule.Path},
+			{"deny", rule.Deny},
+			{"allow", rule.Allow},
+			{"deny_symbols", rule.DenySymbols},
+			{"allow_blank_imports", rule.AllowBlankImports},
+		}
+		for _, f := range fields {
+			if err := expandAll(fmt.Sprintf("rules[%d].%s", i, f.name), f.values); err != nil {
+				return err
+			}
+		}
+
+		if len(rule.RequireAlias) > 0 {
+			aliases := make(map[string]string, len(rule.RequireAlias))
+			for pattern, alias := range rule.RequireAlias {
+				expanded, err := v.expand(pattern)
+				if err != nil {
+					return fmt.Errorf("rules[%d].require_alias: %w", i, err)
+				}
+				aliases[expanded] = alias
+			}
+			rule.RequireAlias = aliases
+		}
+	}
+
+	if m := config.Modules; m != nil {
+		if err := expandAll("modules.deny", m.Deny); err != nil {
+			return err
+		}
+		if err := expandAll("modules.allow", m.Allow); err != nil {
+			return err
+		}
+
+		if len(m.Versions) > 0 {
+			versions := make(map[string]string, len(m.Versions))
+			for pattern, constraint := range m.Versions {
+				expanded, err := v.expand(pattern)
+				if err != nil {
+					return fmt.Errorf("modules.versions: %w", err)
+				}
+				if versions[expanded], err = v.expand(constraint); err != nil {
+					return fmt.Errorf("modules.versions[%q]: %w", pattern, err)
+				}
+			}
+			m.Versions = versions
+		}
+
+		if len(m.BannedVersions) > 0 {
+			banned := make(map[string][]string, len(m.BannedVersions))
+			for pattern, versions := range m.BannedVersions {
+				expanded, err := v.expand(pattern)
+				if err != nil {
+					return fmt.Errorf("modules.banned_versions: %w", err)
+				}
+				if err := expandAll(fmt.Sprintf("modules.banned_versions[%q]", pattern), versions); err != nil {
+					return err
+				}
+				banned[expanded] = versions
+			}
+			m.BannedVersions = banned
+		}
+	}
+
+	if l := config.Licenses; l != nil {
+		if err := expandAll("licenses.allow", l.Allow); err != nil {
+			return err
+		}
+		if err := expandAll("licenses.deny", l.Deny); err != nil {
+			return err
+		}
+	}
+
+	for i := range config.Deprecate {
+		expanded, err := v.expand(config.Deprecate[i].Import)
+		if err != nil {
+			return fmt.Errorf("deprecate[%d].import: %w", i, err)
+		}
+		config.Deprecate[i].Import = expanded
+	}
+
+	for i := range config.Exceptions {
+		e := &config.Exceptions[i]
+		if err := expandAll(fmt.Sprintf("exceptions[%d].path", i), e.Path); err != nil {
+			return err
+		}
+		if err := expandAll(fmt.Sprintf("exceptions[%d].packages", i), e.Packages); err != nil {
+			return err
+		}
+		expanded, err := v.expand(e.Import)
+		if err != nil {
+			return fmt.Errorf("exceptions[%d].import: %w", i, err)
+		}
+		e.Import = expanded
+	}
+
+	for i := range config.Groups {
+		if err := expandAll(fmt.Sprintf("groups[%d].packages", i), config.Groups[i].Packages); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,114 @@
+package config_test
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/blck-snwmn/dependencylintgo/analyzer/config"
+)
+
+func TestLoadConfigVariables(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"go.mod": "module example.com/app\n\ngo 1.24\n",
+		"configs/.llinter.yaml": `
+rules:
+  - path: ["${MODULE_ROOT}/internal/**"]
+    deny: ["${MODULE}/internal/legacy/**", "${env:LLINTER_TEST_DENY}"]
+    require_alias:
+      "${MODULE}/pkg/log": applog
+groups:
+  - name: app
+    packages: ["${MODULE}/**"]
+modules:
+  versions:
+    "${MODULE}/sdk": ">= ${env:LLINTER_TEST_SDK_VERSION}"
+  banned_versions:
+    "${MODULE}/sdk": ["${env:LLINTER_TEST_SDK_VERSION}"]
+licenses:
+  deny: ["${env:LLINTER_TEST_LICENSE}"]
+`,
+	})
+	t.Setenv("LLINTER_TEST_DENY", "github.com/ci/only")
+	t.Setenv("LLINTER_TEST_SDK_VERSION", "v1.2.0")
+	t.Setenv("LLINTER_TEST_LICENSE", "GPL-*")
+
+	cfg, err := config.LoadConfig(filepath.Join(dir, "configs", ".llinter.yaml"))
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+
+	rule := cfg.Rules[0]
+	if want := []string{dir + "/internal/**"}; !reflect.DeepEqual(rule.Path, want) {
+		t.Errorf("Expected path %v, got %v", want, rule.Path)
+	}
+	if want := []string{"example.com/app/internal/legacy/**", "github.com/ci/only"}; !reflect.DeepEqual(rule.Deny, want) {
+		t.Errorf("Expected deny %v, got %v", want, rule.Deny)
+	}
+	if want := map[string]string{"example.com/app/pkg/log": "applog"}; !reflect.DeepEqual(rule.RequireAlias, want) {
+		t.Errorf("Expected require_alias %v, got %v", want, rule.RequireAlias)
+	}
+	if want := []string{"example.com/app/**"}; !reflect.DeepEqual(cfg.Groups[0].Packages, want) {
+		t.Errorf("Expected packages %v, got %v", want, cfg.Groups[0].Packages)
+	}
+	if want := map[string]string{"example.com/app/sdk": ">= v1.2.0"}; !reflect.DeepEqual(cfg.Modules.Versions, want) {
+		t.Errorf("Expected versions %v, got %v", want, cfg.Modules.Versions)
+	}
+	if want := map[string][]string{"example.com/app/sdk": {"v1.2.0"}}; !reflect.DeepEqual(cfg.Modules.BannedVersions, want) {
+		t.Errorf("Expected banned_versions %v, got %v", want, cfg.Modules.BannedVersions)
+	}
+	if want := []string{"GPL-*"}; !reflect.DeepEqual(cfg.Licenses.Deny, want) {
+		t.Errorf("Expected licenses.deny %v, got %v", want, cfg.Licenses.Deny)
+	}
+}
+
+func TestLoadConfigUnresolvedVariables(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   map[string]string
+		wantErr string
+	}{
+		{
+			name: "未設定の環境変数",
+			files: map[string]string{
+				".llinter.yaml": "rules:\n  - deny: [\"${env:LLINTER_TEST_UNSET}\"]\n",
+			},
+			wantErr: "rules[0].deny[0]: environment variable LLINTER_TEST_UNSET is not set",
+		},
+		{
+			name: "未知の変数",
+			files: map[string]string{
+				".llinter.yaml": "rules:\n  - path: [\"${ROOT}/**\"]\n",
+			},
+			wantErr: "rules[0].path[0]: unknown variable ${ROOT}",
+		},
+		{
+			name: "バージョン制約の未設定の環境変数",
+			files: map[string]string{
+				".llinter.yaml": "modules:\n  versions:\n    \"example.com/sdk\": \">= ${env:LLINTER_TEST_UNSET}\"\n",
+			},
+			wantErr: `modules.versions["example.com/sdk"]: environment variable LLINTER_TEST_UNSET is not set`,
+		},
+		{
+			name: "go.modがない",
+			files: map[string]string{
+				".llinter.yaml": "groups:\n  - name: app\n    packages: [\"${MODULE}/**\"]\n",
+			},
+			wantErr: "groups[0].packages[0]: cannot resolve ${MODULE}: go.mod not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeFiles(t, dir, tt.files)
+
+			_, err := config.LoadConfig(filepath.Join(dir, ".llinter.yaml"))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
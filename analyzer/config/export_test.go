@@ -0,0 +1,22 @@
+package config
+
+import "gopkg.in/yaml.v3"
+
+// BuildSchema はテストからJSON Schemaを組み立てるために公開する
+var BuildSchema = buildSchema
+
+// MigrateTo はテストから最新ではないバージョンへの書き換えを試すために公開する
+var MigrateTo = migrateTo
+
+// SetMigration はテストの間だけバージョンvからv+1へのmigrationを登録し、元に戻す関数を返す
+func SetMigration(v int, migrate func(root *yaml.Node) error) (restore func()) {
+	prev, ok := migrations[v]
+	migrations[v] = migrate
+	return func() {
+		if ok {
+			migrations[v] = prev
+		} else {
+			delete(migrations, v)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package config
+
+import (
+	"go/ast"
+	"go/build/constraint"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// NewFile はパース済みのファイルからルールの適用判定に使う情報を作る
+// pathはルールのマッチングに使うファイルパス（MatchPathで求める）
+// ビルド制約を読むため、fileはコメント付きでパースしておく必要がある
+func NewFile(path string, file *ast.File) File {
+	return File{
+		Path:         path,
+		IsTest:       strings.HasSuffix(path, "_test.go"),
+		ExternalTest: strings.HasSuffix(file.Name.Name, "_test"),
+		Constraint:   buildConstraint(file),
+		Generated:    ast.IsGenerated(file),
+	}
+}
+
+// buildConstraint はpackage句より前のコメントからファイルのビルド制約を読み取る
+func buildConstraint(file *ast.File) constraint.Expr {
+	var lines []string
+	for _, group := range file.Comments {
+		if group.Pos() >= file.Package {
+			break
+		}
+		for _, c := range group.List {
+			lines = append(lines, c.Text)
+		}
+	}
+	return ParseBuildConstraint(lines)
+}
+
+// MatchPath はルールのマッチングに使うファイルパスを求める
+//
+// rootはパスの基準になるディレクトリで、モジュールモードではファイルを含むモジュールのルート
+// （go.workのワークスペースでも各ファイルが属するモジュールのルート）、階層的な設定ではDiscoverが返したディレクトリだ
+// rootが空の場合（GOPATHモード）は、パッケージのimportパスにファイル名をつないだものを使う
+// 外部テストパッケージ（foo_test）のファイルはテスト対象のパッケージのディレクトリにあるものとする
+func MatchPath(filePath, pkgPath, root string) string {
+	if root != "" {
+		if rel, err := filepath.Rel(root, filePath); err == nil {
+			return filepath.ToSlash(rel)
+		}
+	}
+	return path.Join(strings.TrimSuffix(pkgPath, "_test"), filepath.Base(filePath))
+}
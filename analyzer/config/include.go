@@ -0,0 +1,123 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// errIncludeCycle はinclude/extendsが循環しているときのエラーだ
+var errIncludeCycle = errors.New("config include cycle")
+
+// IncludeError はinclude/extendsで取り込んだ設定ファイルの読み込みエラーだ
+// Chainには最上位の設定ファイルからエラーの起きたファイルまでの経路が入る
+type IncludeError struct {
+	Chain []string
+	Err   error
+}
+
+func (e *IncludeError) Error() string {
+	return fmt.Sprintf("%v (include chain: %s)", e.Err, strings.Join(e.Chain, " -> "))
+}
+
+func (e *IncludeError) Unwrap() error {
+	return e.Err
+}
+
+// IsNotFound は最上位の設定ファイル自体が見つからないエラーか確認する
+// 取り込んだ設定ファイルが見つからない場合は設定の誤りなのでfalseを返す
+func IsNotFound(err error) bool {
+	var includeErr *IncludeError
+	return errors.Is(err, fs.ErrNotExist) && !errors.As(err, &includeErr)
+}
+
+// loadConfig は設定ファイルを読み込み、include/extendsを再帰的に解決する
+// chainはここまでに読み込んだ設定ファイルの経路
+func loadConfig(configPath string, chain []string) (*Config, error) {
+	chain = append(slices.Clone(chain), configPath)
+	if slices.Contains(chain[:len(chain)-1], configPath) {
+		return nil, &IncludeError{Chain: chain, Err: errIncludeCycle}
+	}
+
+	config, err := readConfigFile(configPath)
+	if err != nil {
+		if len(chain) > 1 {
+			return nil, &IncludeError{Chain: chain, Err: err}
+		}
+		return nil, err
+	}
+	// 既定値はまとめる前に各ファイルのルールへ反映し、取り込んだファイルのgeneratedが取り込む側のルールに及ばないようにする
+	applyDefaults(config)
+
+	load := func(paths []string) ([]*Config, error) {
+		configs := make([]*Config, 0, len(paths))
+		for _, path := range paths {
+			// 相対パスは取り込む側の設定ファイルからの相対とする
+			if !filepath.IsAbs(path) {
+				path = filepath.Join(filepath.Dir(configPath), path)
+			}
+			c, err := loadConfig(path, chain)
+			if err != nil {
+				return nil, err
+			}
+			configs = append(configs, c)
+		}
+		return configs, nil
+	}
+
+	includes, err := load(config.Include)
+	if err != nil {
+		return nil, err
+	}
+	extends, err := load(config.Extends)
+	if err != nil {
+		return nil, err
+	}
+
+	// include、このファイル、extendsの順に優先する
+	merged := append(includes, config)
+	merged = append(merged, extends...)
+	result := mergeConfigs(merged)
+	result.Root = config.Root
+	return result, nil
+}
+
+// mergeConfigs は優先度の高い順に並んだ設定をまとめる
+//   - rules: 優先度の高い順に連結する（最初に一致したルールが使われる）
+//   - deprecate, exceptions: 優先度の高い順に連結する
+//   - groups: 同じ名前のグループは優先度の高いものを使う
+//   - generated: 最初に指定されている値を使う（各ファイルのルールには読み込んだときに反映済み）
+func mergeConfigs(configs []*Config) *Config {
+	merged := &Config{}
+	seenGroups := make(map[string]bool)
+	for _, c := range configs {
+		merged.Rules = append(merged.Rules, c.Rules...)
+		merged.Deprecate = append(merged.Deprecate, c.Deprecate...)
+		merged.Exceptions = append(merged.Exceptions, c.Exceptions...)
+
+		for _, group := range c.Groups {
+			if seenGroups[group.Name] {
+				continue
+			}
+			seenGroups[group.Name] = true
+			merged.Groups = append(merged.Groups, group)
+		}
+
+		if merged.Generated == "" {
+			merged.Generated = c.Generated
+		}
+		if merged.Metrics == nil {
+			merged.Metrics = c.Metrics
+		}
+		if merged.Modules == nil {
+			merged.Modules = c.Modules
+		}
+		if merged.Licenses == nil {
+			merged.Licenses = c.Licenses
+		}
+	}
+	return merged
+}
@@ -0,0 +1,195 @@
+package config_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/blck-snwmn/dependencylintgo/analyzer/config"
+)
+
+// writeFiles はテスト用の設定ファイルをまとめて作成する
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write test config file: %v", err)
+		}
+	}
+}
+
+func TestLoadConfigWithIncludeAndExtends(t *testing.T) {
+	tempDir := t.TempDir()
+	writeFiles(t, tempDir, map[string]string{
+		".llinter.yaml": `
+include: ["shared/org.yaml"]
+extends: ["shared/base.yaml"]
+rules:
+  - path: ["local/*.go"]
+    deny: ["local"]
+groups:
+  - name: billing
+    packages: ["local/billing/**"]
+`,
+		// includeのパスはshared/org.yamlからの相対
+		"shared/org.yaml": `
+extends: ["org-base.yaml"]
+rules:
+  - path: ["**/*.go"]
+    deny: ["org"]
+generated: skip
+`,
+		"shared/org-base.yaml": `
+rules:
+  - path: ["**/*.go"]
+    deny: ["org-base"]
+`,
+		"shared/base.yaml": `
+rules:
+  - path: ["**/*.go"]
+    deny: ["base"]
+groups:
+  - name: billing
+    packages: ["base/billing/**"]
+  - name: users
+    packages: ["base/users/**"]
+generated: check
+`,
+	})
+
+	cfg, err := config.LoadConfig(filepath.Join(tempDir, ".llinter.yaml"))
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+
+	// include、このファイル、extendsの順に並ぶ
+	var denies []string
+	for _, rule := range cfg.Rules {
+		denies = append(denies, rule.Deny...)
+	}
+	want := "org,org-base,local,base"
+	if got := strings.Join(denies, ","); got != want {
+		t.Errorf("Unexpected rule order: got %s, want %s", got, want)
+	}
+
+	// 同じ名前のグループは優先度の高いものが使われる
+	if len(cfg.Groups) != 2 || cfg.Groups[0].Packages[0] != "local/billing/**" || cfg.Groups[1].Name != "users" {
+		t.Errorf("Unexpected groups: %v", cfg.Groups)
+	}
+
+	if cfg.Generated != config.GeneratedSkip {
+		t.Errorf("Expected generated %q, got %q", config.GeneratedSkip, cfg.Generated)
+	}
+}
+
+// TestLoadConfigIncludeGenerated は取り込んだファイルのgeneratedが取り込む側のルールに及ばないかテストする
+func TestLoadConfigIncludeGenerated(t *testing.T) {
+	tempDir := t.TempDir()
+	writeFiles(t, tempDir, map[string]string{
+		".llinter.yaml": `
+include: ["shared.yaml"]
+extends: ["base.yaml"]
+rules:
+  - path: ["gen/*.go"]
+    deny: ["local"]
+`,
+		"shared.yaml": `
+generated: skip
+rules:
+  - path: ["shared/*.go"]
+    deny: ["shared"]
+`,
+		"base.yaml": `
+generated: only
+rules:
+  - path: ["base/*.go"]
+    deny: ["base"]
+`,
+	})
+
+	cfg, err := config.LoadConfig(filepath.Join(tempDir, ".llinter.yaml"))
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+
+	// 各ルールはそれを書いたファイルのgeneratedに従う
+	want := map[string]string{
+		"shared": config.GeneratedSkip,
+		"local":  config.GeneratedCheck,
+		"base":   config.GeneratedOnly,
+	}
+	for _, rule := range cfg.Rules {
+		if got := rule.Generated; got != want[rule.Deny[0]] {
+			t.Errorf("Rule denying %s: generated = %q, want %q", rule.Deny[0], got, want[rule.Deny[0]])
+		}
+	}
+
+	generated := config.File{Path: "gen/a.pb.go", Generated: true}
+	if got := config.FindMatchingRuleForFile(cfg, generated); got == nil || got.Deny[0] != "local" {
+		t.Errorf("FindMatchingRuleForFile() = %v, want the rule denying local", got)
+	}
+}
+
+func TestLoadConfigIncludeCycle(t *testing.T) {
+	tempDir := t.TempDir()
+	writeFiles(t, tempDir, map[string]string{
+		".llinter.yaml": `extends: ["a.yaml"]`,
+		"a.yaml":        `extends: ["b.yaml"]`,
+		"b.yaml":        `include: ["a.yaml"]`,
+	})
+
+	_, err := config.LoadConfig(filepath.Join(tempDir, ".llinter.yaml"))
+	if err == nil {
+		t.Fatal("Expected include cycle error, got nil")
+	}
+
+	var includeErr *config.IncludeError
+	if !errors.As(err, &includeErr) {
+		t.Fatalf("Expected IncludeError, got %T: %v", err, err)
+	}
+	var chain []string
+	for _, path := range includeErr.Chain {
+		chain = append(chain, filepath.Base(path))
+	}
+	want := ".llinter.yaml -> a.yaml -> b.yaml -> a.yaml"
+	if got := strings.Join(chain, " -> "); got != want {
+		t.Errorf("Unexpected include chain: got %s, want %s", got, want)
+	}
+}
+
+func TestLoadConfigIncludeErrors(t *testing.T) {
+	tempDir := t.TempDir()
+	writeFiles(t, tempDir, map[string]string{
+		".llinter.yaml": `include: ["missing.yaml"]`,
+		"invalid.yaml":  `extends: ["bad.yaml"]`,
+		"bad.yaml": `
+rules:
+  - path: ["**/*.go"]
+    tests: sometimes
+`,
+	})
+
+	// 取り込んだ設定ファイルがないのは設定の誤り
+	_, err := config.LoadConfig(filepath.Join(tempDir, ".llinter.yaml"))
+	if err == nil || config.IsNotFound(err) {
+		t.Errorf("Expected include error, got %v", err)
+	}
+
+	// 取り込んだ設定ファイルのエラーには取り込みの経路が含まれる
+	_, err = config.LoadConfig(filepath.Join(tempDir, "invalid.yaml"))
+	if err == nil || !strings.Contains(err.Error(), "bad.yaml") || !strings.Contains(err.Error(), "include chain") {
+		t.Errorf("Expected error with include chain, got %v", err)
+	}
+
+	// 最上位の設定ファイルがないのは見つからないエラー
+	_, err = config.LoadConfig(filepath.Join(tempDir, "nothing.yaml"))
+	if !config.IsNotFound(err) {
+		t.Errorf("Expected not found error, got %v", err)
+	}
+}
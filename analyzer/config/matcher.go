@@ -0,0 +1,234 @@
+package config
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// Patterns はコンパイル済みのパターンの集合だ
+// 同じパターンで何度も照合する場合は、IsImportPathMatchedなどの代わりにこちらを使う
+type Patterns struct {
+	patterns []compiledPattern
+	match    func(p *compiledPattern, s string) bool
+}
+
+// compiledPattern は照合のために前処理したパターンだ
+type compiledPattern struct {
+	raw      string
+	wildcard bool     // *を含むか
+	prefix   string   // /**で終わるimportパスパターンの前半
+	segments []string // **を含むファイルパスパターンの要素
+}
+
+// CompileImportPatterns はimportパスパターンをコンパイルする
+func CompileImportPatterns(patterns []string) Patterns {
+	return compilePatterns(patterns, matchImportPattern)
+}
+
+// CompileFilePatterns はファイルパスパターンをコンパイルする
+func CompileFilePatterns(patterns []string) Patterns {
+	return compilePatterns(patterns, matchFilePattern)
+}
+
+func compilePatterns(patterns []string, match func(p *compiledPattern, s string) bool) Patterns {
+	compiled := make([]compiledPattern, 0, len(patterns))
+	for _, pattern := range patterns {
+		p := compiledPattern{
+			raw:      pattern,
+			wildcard: strings.Contains(pattern, "*"),
+		}
+		if prefix, ok := strings.CutSuffix(pattern, "/**"); ok {
+			p.prefix = prefix
+		}
+		if strings.Contains(pattern, "**") {
+			p.segments = strings.Split(pattern, "/")
+		}
+		compiled = append(compiled, p)
+	}
+	return Patterns{patterns: compiled, match: match}
+}
+
+// Len はパターンの数を返す
+func (p Patterns) Len() int {
+	return len(p.patterns)
+}
+
+// Match は文字列に最初にマッチしたパターンを返す
+func (p Patterns) Match(s string) (string, bool) {
+	for i := range p.patterns {
+		if p.match(&p.patterns[i], s) {
+			return p.patterns[i].raw, true
+		}
+	}
+	return "", false
+}
+
+// matchImportPattern はインポートパスがパターンにマッチするか確認する
+func matchImportPattern(p *compiledPattern, importPath string) bool {
+	// 完全一致のケース
+	if p.raw == importPath {
+		return true
+	}
+
+	// ** だけのパターンはすべてのimportにマッチする
+	if p.raw == "**" {
+		return true
+	}
+
+	// ワイルドカードを含むパターン
+	if p.wildcard {
+		matched, err := filepath.Match(p.raw, importPath)
+		if err == nil && matched {
+			return true
+		}
+	}
+
+	// プレフィックスマッチ（サブパッケージ含む）
+	if p.prefix != "" && strings.HasPrefix(importPath, p.prefix) {
+		return true
+	}
+
+	return false
+}
+
+// matchFilePattern はファイルパスがパターンにマッチするか確認する
+func matchFilePattern(p *compiledPattern, filePath string) bool {
+	matched, err := filepath.Match(p.raw, filePath)
+	if err == nil && matched {
+		return true
+	}
+
+	// より複雑なグロブパターン（**など）のサポート
+	if p.segments != nil {
+		parts := strings.Split(p.raw, "**")
+		if len(parts) == 2 {
+			if strings.HasPrefix(filePath, parts[0]) && strings.HasSuffix(filePath, parts[1]) {
+				return true
+			}
+		}
+
+		// ディレクトリ単位の ** は0個以上のディレクトリにマッチする
+		if matchSegments(p.segments, strings.Split(filePath, "/")) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchSegments はパスの要素ごとにグロブパターンを照合する
+func matchSegments(patterns, segments []string) bool {
+	if len(patterns) == 0 {
+		return len(segments) == 0
+	}
+
+	if patterns[0] == "**" {
+		for i := 0; i <= len(segments); i++ {
+			if matchSegments(patterns[1:], segments[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if len(segments) == 0 {
+		return false
+	}
+	matched, err := filepath.Match(patterns[0], segments[0])
+	if err != nil || !matched {
+		return false
+	}
+	return matchSegments(patterns[1:], segments[1:])
+}
+
+// RuleSet はルールの適用先を求めるためにコンパイルしたルールの集合だ
+type RuleSet struct {
+	rules []Rule // 設定全体の既定値を反映したルール
+	paths []Patterns
+}
+
+// CompileRules は設定のルールをコンパイルする
+// 設定全体の既定値（generated）はコンパイルしたルールに反映し、設定自体は変更しない
+func CompileRules(config *Config) *RuleSet {
+	s := &RuleSet{}
+	if config == nil {
+		return s
+	}
+	s.rules = slices.Clone(config.Rules)
+	s.paths = make([]Patterns, len(s.rules))
+	for i := range s.rules {
+		s.rules[i].applyDefaults(config)
+		s.paths[i] = CompileFilePatterns(s.rules[i].Path)
+	}
+	return s
+}
+
+// Match はファイルに適用するルールとその位置を返す
+// 最初に一致したルールが使われる。一致するルールがなければnilと-1を返す
+func (s *RuleSet) Match(file File) (*Rule, int) {
+	for i := range s.paths {
+		if m := s.match(i, file); m.Applied {
+			return m.Rule, i
+		}
+	}
+	return nil, -1
+}
+
+// MatchInherited はファイルに適用するルールと、上位のディレクトリの設定ファイルから引き継ぐルールの位置を返す
+// Discoverでまとめた設定では、設定ファイルごとに最初に一致したルールを優先される順に返す
+// 1つの設定ファイルから読み込んだ設定では、Matchと同じく最初に一致したルールだけを返す
+func (s *RuleSet) MatchInherited(file File) []int {
+	var indices []int
+	level := -1
+	for i := range s.paths {
+		if s.rules[i].level <= level {
+			continue
+		}
+		if m := s.match(i, file); m.Applied {
+			indices = append(indices, i)
+			level = s.rules[i].level
+		}
+	}
+	return indices
+}
+
+// RuleMatch はルールがファイルに適用されるかの判定の詳細だ
+type RuleMatch struct {
+	Index       int    // 設定のrules中の位置
+	Rule        *Rule  // 判定したルール
+	PathPattern string // ファイルパスに一致したpathパターン。一致しなければ空
+	Reason      string // pathは一致したが適用されない理由
+	Applied     bool   // ルールが適用されるか
+}
+
+// Trace はファイルに適用するルールを決めるまでに判定したルールを順に返す
+// 最後の要素が適用されたルールになる。適用されるルールがなければすべてのルールの判定を返す
+func (s *RuleSet) Trace(file File) []RuleMatch {
+	var matches []RuleMatch
+	for i := range s.paths {
+		m := s.match(i, file)
+		matches = append(matches, m)
+		if m.Applied {
+			break
+		}
+	}
+	return matches
+}
+
+func (s *RuleSet) match(i int, file File) RuleMatch {
+	m := RuleMatch{Index: i, Rule: &s.rules[i]}
+
+	pattern, ok := s.paths[i].Match(file.Path)
+	if !ok {
+		return m
+	}
+	m.PathPattern = pattern
+
+	if m.Reason = m.Rule.excludeReason(file); m.Reason != "" {
+		return m
+	}
+
+	m.Applied = true
+	return m
+}
@@ -0,0 +1,67 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+)
+
+// errNoModule はgo.modが見つからないときのエラーだ
+var errNoModule = errors.New("go.mod not found")
+
+// FindModule はdirから親ディレクトリへ遡ってgo.modを探し、モジュールルートとモジュールパスを返す
+func FindModule(dir string) (root, modulePath string, err error) {
+	dir, err = filepath.Abs(dir)
+	if err != nil {
+		return "", "", err
+	}
+
+	for {
+		gomod := filepath.Join(dir, "go.mod")
+		data, err := os.ReadFile(gomod)
+		if err == nil {
+			modulePath := modfile.ModulePath(data)
+			if modulePath == "" {
+				return "", "", fmt.Errorf("%s: no module directive", gomod)
+			}
+			return dir, modulePath, nil
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", "", errNoModule
+		}
+		dir = parent
+	}
+}
+
+// ResolveModule はimportパスを提供するモジュールを、go.modで最も長く一致するrequireから求める
+// replaceがあれば置き換え先も返す。ローカルのディレクトリへの置き換えはVersionが空になる
+func ResolveModule(f *modfile.File, importPath string) (required, replacement module.Version, ok bool) {
+	var found *modfile.Require
+	for _, r := range f.Require {
+		if importPath != r.Mod.Path && !strings.HasPrefix(importPath, r.Mod.Path+"/") {
+			continue
+		}
+		if found == nil || len(r.Mod.Path) > len(found.Mod.Path) {
+			found = r
+		}
+	}
+	if found == nil {
+		return module.Version{}, module.Version{}, false
+	}
+
+	required = found.Mod
+	for _, r := range f.Replace {
+		// バージョン指定のあるreplaceは、そのバージョンのときだけ適用される
+		if r.Old.Path == required.Path && (r.Old.Version == "" || r.Old.Version == required.Version) {
+			replacement = r.New
+		}
+	}
+	return required, replacement, true
+}
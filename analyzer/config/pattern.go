@@ -7,71 +7,126 @@ import (
 
 // IsFilePathMatched はファイルパスがパターンにマッチするか確認する
 func IsFilePathMatched(filePath string, patterns []string) bool {
-	if len(patterns) == 0 {
-		return false
-	}
+	_, ok := CompileFilePatterns(patterns).Match(filePath)
+	return ok
+}
+
+// IsImportPathMatched はインポートパスがパターンにマッチするか確認する
+func IsImportPathMatched(importPath string, patterns []string) bool {
+	_, ok := CompileImportPatterns(patterns).Match(importPath)
+	return ok
+}
 
+// IsSymbolMatched はパッケージのシンボルがパターンにマッチするか確認する
+// パターンは「importパスパターン.シンボル名パターン」の形式（例: fmt.Println, time.*）
+func IsSymbolMatched(pkgPath, name string, patterns []string) bool {
 	for _, pattern := range patterns {
-		matched, err := filepath.Match(pattern, filePath)
-		if err == nil && matched {
-			return true
+		// importパスの最後の要素にもドットが含まれることがある（例: gopkg.in/yaml.v3）が、
+		// シンボル名には含まれないので、最後のスラッシュ以降の最後のドットで区切る
+		slash := strings.LastIndex(pattern, "/")
+		dot := strings.LastIndex(pattern, ".")
+		if dot < 0 || dot < slash {
+			continue
 		}
+		pkgPattern := pattern[:dot]
+		namePattern := pattern[dot+1:]
 
-		// より複雑なグロブパターン（**など）のサポート
-		if strings.Contains(pattern, "**") {
-			parts := strings.Split(pattern, "**")
-			if len(parts) == 2 {
-				if strings.HasPrefix(filePath, parts[0]) && strings.HasSuffix(filePath, parts[1]) {
-					return true
-				}
-			}
+		if !IsImportPathMatched(pkgPath, []string{pkgPattern}) {
+			continue
+		}
+		matched, err := filepath.Match(namePattern, name)
+		if err == nil && matched {
+			return true
 		}
 	}
 
 	return false
 }
 
-// IsImportPathMatched はインポートパスがパターンにマッチするか確認する
-func IsImportPathMatched(importPath string, patterns []string) bool {
-	if len(patterns) == 0 {
-		return false
-	}
+// FindMatchingRule はファイルパスに適用するルールを見つける
+// テストファイルかどうかはファイル名から判断する
+func FindMatchingRule(config *Config, filePath string) *Rule {
+	return FindMatchingRuleForFile(config, File{
+		Path:   filePath,
+		IsTest: strings.HasSuffix(filePath, "_test.go"),
+	})
+}
 
-	for _, pattern := range patterns {
-		// 完全一致のケース
-		if pattern == importPath {
-			return true
-		}
+// FindMatchingRuleForFile はファイルに適用するルールを見つける
+func FindMatchingRuleForFile(config *Config, file File) *Rule {
+	rule, _ := CompileRules(config).Match(file)
+	return rule
+}
 
-		// ワイルドカードを含むパターン
-		if strings.Contains(pattern, "*") {
-			matched, err := filepath.Match(pattern, importPath)
-			if err == nil && matched {
-				return true
-			}
+// AppliesTo はルールがファイルに適用されるか確認する
+// 設定全体の既定値（generated）はLoadConfigで読み込んだときにルールへ反映される
+func (r *Rule) AppliesTo(file File) bool {
+	return IsFilePathMatched(file.Path, r.Path) && r.excludeReason(file) == ""
+}
+
+// excludeReason はファイルパス以外のルールの条件でファイルが対象外になる理由を返す
+// 対象になる場合は空文字列を返す
+func (r *Rule) excludeReason(file File) string {
+	switch r.Tests {
+	case TestsExclude:
+		if file.IsTest {
+			return "test files are excluded (tests: exclude)"
 		}
+	case TestsOnly:
+		if !file.IsTest {
+			return "only test files are checked (tests: only)"
+		}
+	}
 
-		// プレフィックスマッチ（サブパッケージ含む）
-		if strings.HasSuffix(pattern, "/**") {
-			prefix := strings.TrimSuffix(pattern, "/**")
-			if strings.HasPrefix(importPath, prefix) {
-				return true
+	// test_packageはテストファイルにだけ意味を持つ
+	if file.IsTest {
+		switch r.TestPackage {
+		case TestPackageInternal:
+			if file.ExternalTest {
+				return "only internal test packages are checked (test_package: internal)"
+			}
+		case TestPackageExternal:
+			if !file.ExternalTest {
+				return "only external test packages are checked (test_package: external)"
 			}
 		}
 	}
 
-	return false
+	if reason := GeneratedReason(r.Generated, file); reason != "" {
+		return reason
+	}
+
+	if !r.matchesBuild(file) {
+		return "build constraints do not match (goos/build_tags)"
+	}
+	return ""
 }
 
-// FindMatchingRule はファイルパスに適用するルールを見つける
-func FindMatchingRule(config *Config, filePath string) *Rule {
-	if config == nil || len(config.Rules) == 0 {
+// GeneratedReason は生成コードの扱い（skip|check|only）でファイルが対象外になる理由を返す
+// 対象になる場合は空文字列を返す
+func GeneratedReason(mode string, file File) string {
+	switch mode {
+	case GeneratedSkip:
+		if file.Generated {
+			return "generated code is skipped (generated: skip)"
+		}
+	case GeneratedOnly:
+		if !file.Generated {
+			return "only generated code is checked (generated: only)"
+		}
+	}
+	return ""
+}
+
+// FindGroup はパッケージが属するグループを見つける
+func FindGroup(config *Config, pkgPath string) *Group {
+	if config == nil || len(config.Groups) == 0 {
 		return nil
 	}
 
-	for _, rule := range config.Rules {
-		if IsFilePathMatched(filePath, rule.Path) {
-			return &rule
+	for _, group := range config.Groups {
+		if IsImportPathMatched(pkgPath, group.Packages) {
+			return &group
 		}
 	}
 
@@ -1,6 +1,8 @@
 package config_test
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/blck-snwmn/dependencylintgo/analyzer/config"
@@ -49,6 +51,24 @@ func TestIsFilePathMatched(t *testing.T) {
 			patterns: []string{"src/example/**/*.go"},
 			want:     true,
 		},
+		{
+			name:     "再帰的ワイルドカード（深いサブディレクトリ）",
+			filePath: "src/example/sub/deep/main.go",
+			patterns: []string{"src/example/**/*.go"},
+			want:     true,
+		},
+		{
+			name:     "再帰的ワイルドカード（直下）",
+			filePath: "src/example/main.go",
+			patterns: []string{"src/example/**/*.go"},
+			want:     true,
+		},
+		{
+			name:     "先頭の再帰的ワイルドカード",
+			filePath: "src/example/sub/main.go",
+			patterns: []string{"**/*.go"},
+			want:     true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -104,6 +124,12 @@ func TestIsImportPathMatched(t *testing.T) {
 			patterns:   []string{"github.com/example/**"},
 			want:       true,
 		},
+		{
+			name:       "すべてにマッチ",
+			importPath: "net/http/httptest",
+			patterns:   []string{"**"},
+			want:       true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -116,6 +142,89 @@ func TestIsImportPathMatched(t *testing.T) {
 	}
 }
 
+func TestIsSymbolMatched(t *testing.T) {
+	tests := []struct {
+		name     string
+		pkgPath  string
+		symbol   string
+		patterns []string
+		want     bool
+	}{
+		{
+			name:     "完全一致",
+			pkgPath:  "fmt",
+			symbol:   "Println",
+			patterns: []string{"fmt.Println"},
+			want:     true,
+		},
+		{
+			name:     "同じパッケージの別シンボル",
+			pkgPath:  "fmt",
+			symbol:   "Sprintf",
+			patterns: []string{"fmt.Println"},
+			want:     false,
+		},
+		{
+			name:     "シンボル名のワイルドカード",
+			pkgPath:  "fmt",
+			symbol:   "Printf",
+			patterns: []string{"fmt.Print*"},
+			want:     true,
+		},
+		{
+			name:     "ドットを含むimportパス",
+			pkgPath:  "github.com/example/pkg",
+			symbol:   "Do",
+			patterns: []string{"github.com/example/pkg.Do"},
+			want:     true,
+		},
+		{
+			name:     "importパスのワイルドカード",
+			pkgPath:  "github.com/example/pkg/sub",
+			symbol:   "Do",
+			patterns: []string{"github.com/example/**.Do"},
+			want:     true,
+		},
+		{
+			name:     "最後の要素にドットを含むimportパス",
+			pkgPath:  "gopkg.in/yaml.v3",
+			symbol:   "Marshal",
+			patterns: []string{"gopkg.in/yaml.v3.Marshal"},
+			want:     true,
+		},
+		{
+			name:     "最後の要素にドットを含むimportパスの別シンボル",
+			pkgPath:  "gopkg.in/yaml.v3",
+			symbol:   "Unmarshal",
+			patterns: []string{"gopkg.in/yaml.v3.Marshal"},
+			want:     false,
+		},
+		{
+			name:     "シンボル名のないドットを含むimportパスは無視",
+			pkgPath:  "github.com/example/pkg",
+			symbol:   "pkg",
+			patterns: []string{"github.com/example/pkg"},
+			want:     false,
+		},
+		{
+			name:     "シンボル名のないパターンは無視",
+			pkgPath:  "fmt",
+			symbol:   "Println",
+			patterns: []string{"fmt"},
+			want:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := config.IsSymbolMatched(tt.pkgPath, tt.symbol, tt.patterns)
+			if got != tt.want {
+				t.Errorf("IsSymbolMatched() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestFindMatchingRule(t *testing.T) {
 	cfg := &config.Config{
 		Rules: []config.Rule{
@@ -173,3 +282,228 @@ func TestFindMatchingRule(t *testing.T) {
 		})
 	}
 }
+
+func TestFindGroup(t *testing.T) {
+	cfg := &config.Config{
+		Groups: []config.Group{
+			{Name: "billing", Packages: []string{"github.com/acme/billing/**"}},
+			{Name: "users", Packages: []string{"github.com/acme/users/**"}},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		pkgPath string
+		want    string
+	}{
+		{
+			name:    "最初のグループに一致",
+			pkgPath: "github.com/acme/billing/api",
+			want:    "billing",
+		},
+		{
+			name:    "2番目のグループに一致",
+			pkgPath: "github.com/acme/users/core/model",
+			want:    "users",
+		},
+		{
+			name:    "どのグループにも一致しない",
+			pkgPath: "github.com/acme/other",
+			want:    "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := config.FindGroup(cfg, tt.pkgPath)
+			name := ""
+			if got != nil {
+				name = got.Name
+			}
+			if name != tt.want {
+				t.Errorf("FindGroup() = %q, want %q", name, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindMatchingRuleForFile(t *testing.T) {
+	cfg := &config.Config{
+		Rules: []config.Rule{
+			{Path: []string{"src/**/*.go"}, Tests: config.TestsExclude, Deny: []string{"github.com/stretchr/testify/**"}},
+			{Path: []string{"src/**/*.go"}, Tests: config.TestsOnly, TestPackage: config.TestPackageInternal, Deny: []string{"os"}},
+			{Path: []string{"src/**/*.go"}, Tests: config.TestsOnly, TestPackage: config.TestPackageExternal, Deny: []string{"unsafe"}},
+		},
+	}
+
+	tests := []struct {
+		name string
+		file config.File
+		want int // 一致するルールのインデックス（-1は一致なし）
+	}{
+		{
+			name: "本番コード",
+			file: config.File{Path: "src/pkg/main.go"},
+			want: 0,
+		},
+		{
+			name: "内部テスト",
+			file: config.File{Path: "src/pkg/main_test.go", IsTest: true},
+			want: 1,
+		},
+		{
+			name: "外部テスト",
+			file: config.File{Path: "src/pkg/main_test.go", IsTest: true, ExternalTest: true},
+			want: 2,
+		},
+		{
+			name: "パスが一致しない",
+			file: config.File{Path: "other/main_test.go", IsTest: true},
+			want: -1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := config.FindMatchingRuleForFile(cfg, tt.file)
+			if tt.want < 0 {
+				if got != nil {
+					t.Errorf("FindMatchingRuleForFile() = %v, want nil", got)
+				}
+				return
+			}
+			if got == nil || got.Deny[0] != cfg.Rules[tt.want].Deny[0] {
+				t.Errorf("FindMatchingRuleForFile() = %v, want %v", got, cfg.Rules[tt.want])
+			}
+		})
+	}
+}
+
+func TestFindMatchingRuleForGenerated(t *testing.T) {
+	rules := []config.Rule{
+		{Path: []string{"src/**/*.go"}, Generated: config.GeneratedOnly, Deny: []string{"unsafe"}},
+		{Path: []string{"src/**/*.go"}, Deny: []string{"fmt"}},
+	}
+
+	tests := []struct {
+		name      string
+		generated string // 設定全体の既定値
+		file      config.File
+		want      string // 一致するルールのDeny[0]（空文字は一致なし）
+	}{
+		{
+			name: "生成コードにonlyのルールが適用される",
+			file: config.File{Path: "src/pkg/a.pb.go", Generated: true},
+			want: "unsafe",
+		},
+		{
+			name: "手書きコードにはonlyのルールが適用されない",
+			file: config.File{Path: "src/pkg/a.go"},
+			want: "fmt",
+		},
+		{
+			name:      "既定値skipは指定のないルールに適用される",
+			generated: config.GeneratedSkip,
+			file:      config.File{Path: "src/pkg/b.pb.go", Generated: true},
+			want:      "unsafe",
+		},
+		{
+			name:      "既定値onlyで手書きコードはどのルールにも一致しない",
+			generated: config.GeneratedOnly,
+			file:      config.File{Path: "src/pkg/a.go"},
+			want:      "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{Rules: rules, Generated: tt.generated}
+			got := config.FindMatchingRuleForFile(cfg, tt.file)
+			deny := ""
+			if got != nil {
+				deny = got.Deny[0]
+			}
+			if deny != tt.want {
+				t.Errorf("FindMatchingRuleForFile() deny = %q, want %q", deny, tt.want)
+			}
+		})
+	}
+}
+
+func TestGeneratedDefault(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), ".llinter.yaml")
+	content := `generated: skip
+rules:
+  - path: ["src/**/*.go"]
+    deny: ["unsafe"]
+  - path: ["src/**/*.go"]
+    generated: check
+    deny: ["fmt"]
+`
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := config.LoadConfig(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	generated := config.File{Path: "src/pkg/a.pb.go", Generated: true}
+	handwritten := config.File{Path: "src/pkg/a.go"}
+
+	// ルール単体の判定とルールの集合からの判定が一致する
+	if cfg.Rules[0].AppliesTo(generated) {
+		t.Error("AppliesTo() = true for generated code, want false (generated: skip)")
+	}
+	if !cfg.Rules[0].AppliesTo(handwritten) {
+		t.Error("AppliesTo() = false for handwritten code, want true")
+	}
+	if !cfg.Rules[1].AppliesTo(generated) {
+		t.Error("AppliesTo() = false for generated code, want true (generated: check)")
+	}
+	if got := config.FindMatchingRuleForFile(cfg, generated); got == nil || got.Deny[0] != "fmt" {
+		t.Errorf("FindMatchingRuleForFile() = %v, want the rule denying fmt", got)
+	}
+	if got := config.FindMatchingRuleForFile(cfg, handwritten); got == nil || got.Deny[0] != "unsafe" {
+		t.Errorf("FindMatchingRuleForFile() = %v, want the rule denying unsafe", got)
+	}
+
+	// ディレクトリごとの設定ファイルの既定値は、ほかのディレクトリの設定ファイルのルールに影響しない
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"go.mod": "module example.com/repo\n",
+		".llinter.yaml": `
+rules:
+  - path: ["**/*.go"]
+    deny: ["repo"]
+`,
+		"gen/.llinter.yaml": `
+generated: skip
+rules:
+  - path: ["*.go"]
+    deny: ["gen"]
+`,
+	})
+	discovered, _, err := config.Discover(filepath.Join(dir, "gen"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := config.FindMatchingRuleForFile(discovered, config.File{Path: "gen/a.pb.go", Generated: true}); got == nil || got.Deny[0] != "repo" {
+		t.Errorf("FindMatchingRuleForFile() = %v, want the rule denying repo", got)
+	}
+	if !discovered.Rules[1].AppliesTo(config.File{Path: "gen/a.pb.go", Generated: true}) {
+		t.Error("AppliesTo() = false for the parent rule, want true")
+	}
+
+	// コードで組み立てた設定もコンパイル時に既定値を反映し、設定自体は変更しない
+	built := &config.Config{
+		Generated: config.GeneratedSkip,
+		Rules:     []config.Rule{{Path: []string{"src/**/*.go"}, Deny: []string{"unsafe"}}},
+	}
+	if got := config.FindMatchingRuleForFile(built, generated); got != nil {
+		t.Errorf("FindMatchingRuleForFile() = %v, want nil", got)
+	}
+	if built.Rules[0].Generated != "" {
+		t.Errorf("CompileRules() modified the config: generated = %q", built.Rules[0].Generated)
+	}
+}
@@ -0,0 +1,110 @@
+package config
+
+import (
+	_ "embed"
+	"encoding/json"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+//go:generate go test -run TestJSONSchema -update
+
+// SchemaURL は公開しているJSON SchemaのURLだ
+const SchemaURL = "https://raw.githubusercontent.com/blck-snwmn/dependencylintgo/main/analyzer/config/llinter.schema.json"
+
+// JSONSchema は設定ファイル（Config）のJSON Schemaだ
+// 構造体の定義とフィールドのコメントから go generate で生成する
+//
+//go:embed llinter.schema.json
+var JSONSchema []byte
+
+// enumPattern はコメント中の「（a|b|c）」を列挙値として取り出す
+var enumPattern = regexp.MustCompile(`（([a-z0-9_]+(?:\|[a-z0-9_]+)+)）`)
+
+// schemaBuilder は構造体からJSON Schemaを組み立てる
+type schemaBuilder struct {
+	// descriptions は「型名」「型名.フィールド名」ごとの説明
+	descriptions map[string]string
+	defs         map[string]any
+}
+
+// buildSchema はConfigのJSON Schemaを組み立てる
+func buildSchema(descriptions map[string]string) ([]byte, error) {
+	b := &schemaBuilder{descriptions: descriptions, defs: make(map[string]any)}
+
+	schema := b.objectSchema(reflect.TypeOf(Config{}))
+	schema["$schema"] = "https://json-schema.org/draft/2020-12/schema"
+	schema["$id"] = SchemaURL
+	schema["title"] = "llinter configuration"
+	schema["$defs"] = b.defs
+
+	data, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+func (b *schemaBuilder) typeSchema(t reflect.Type) map[string]any {
+	// 日付は文字列として書く
+	if t == reflect.TypeOf(Date{}) {
+		return map[string]any{"type": "string", "format": "date"}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return map[string]any{"type": "array", "items": b.typeSchema(t.Elem())}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": b.typeSchema(t.Elem())}
+	case reflect.Pointer:
+		return b.typeSchema(t.Elem())
+	case reflect.Struct:
+		if _, ok := b.defs[t.Name()]; !ok {
+			// 再帰的な型に備えて先に登録しておく
+			b.defs[t.Name()] = nil
+			b.defs[t.Name()] = b.objectSchema(t)
+		}
+		return map[string]any{"$ref": "#/$defs/" + t.Name()}
+	}
+	return map[string]any{}
+}
+
+func (b *schemaBuilder) objectSchema(t reflect.Type) map[string]any {
+	properties := make(map[string]any)
+	for i := range t.NumField() {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		if !field.IsExported() || name == "" || name == "-" {
+			continue
+		}
+
+		property := b.typeSchema(field.Type)
+		if desc := b.descriptions[t.Name()+"."+field.Name]; desc != "" {
+			property["description"] = desc
+			if m := enumPattern.FindStringSubmatch(desc); m != nil && property["type"] == "string" {
+				property["enum"] = strings.Split(m[1], "|")
+			}
+		}
+		properties[name] = property
+	}
+
+	schema := map[string]any{
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+	if desc := b.descriptions[t.Name()]; desc != "" {
+		schema["description"] = desc
+	}
+	return schema
+}
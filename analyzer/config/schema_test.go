@@ -0,0 +1,85 @@
+package config_test
+
+import (
+	"bytes"
+	"flag"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/blck-snwmn/dependencylintgo/analyzer/config"
+)
+
+var update = flag.Bool("update", false, "update llinter.schema.json")
+
+// schemaDescriptions はパッケージのソースから型とフィールドのコメントを集める
+func schemaDescriptions(t *testing.T) map[string]string {
+	t.Helper()
+
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, ".", func(info os.FileInfo) bool {
+		return !strings.HasSuffix(info.Name(), "_test.go")
+	}, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("Failed to parse package: %v", err)
+	}
+
+	descriptions := make(map[string]string)
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Files {
+			for _, decl := range file.Decls {
+				gen, ok := decl.(*ast.GenDecl)
+				if !ok || gen.Tok != token.TYPE {
+					continue
+				}
+				for _, spec := range gen.Specs {
+					typeSpec := spec.(*ast.TypeSpec)
+					structType, ok := typeSpec.Type.(*ast.StructType)
+					if !ok {
+						continue
+					}
+
+					doc := typeSpec.Doc
+					if doc == nil {
+						doc = gen.Doc
+					}
+					descriptions[typeSpec.Name.Name] = strings.TrimSpace(doc.Text())
+
+					for _, field := range structType.Fields.List {
+						comment := field.Comment
+						if comment == nil {
+							comment = field.Doc
+						}
+						for _, name := range field.Names {
+							descriptions[typeSpec.Name.Name+"."+name.Name] = strings.TrimSpace(comment.Text())
+						}
+					}
+				}
+			}
+		}
+	}
+	return descriptions
+}
+
+// TestJSONSchema は埋め込んだJSON Schemaが構造体の定義と一致しているかテストする
+// 更新するには go generate ./analyzer/config を実行する
+func TestJSONSchema(t *testing.T) {
+	got, err := config.BuildSchema(schemaDescriptions(t))
+	if err != nil {
+		t.Fatalf("Failed to build schema: %v", err)
+	}
+
+	if *update {
+		if err := os.WriteFile("llinter.schema.json", got, 0644); err != nil {
+			t.Fatalf("Failed to write schema: %v", err)
+		}
+		return
+	}
+
+	if !bytes.Equal(got, config.JSONSchema) {
+		t.Error("llinter.schema.json is out of date; run go generate ./analyzer/config")
+	}
+}
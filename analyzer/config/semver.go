@@ -0,0 +1,80 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/mod/semver"
+)
+
+// VersionConstraint はカンマ区切りの比較をすべて満たすバージョンに一致する制約だ（例: ">= v1.8.0, < v2"）
+type VersionConstraint struct {
+	raw         string
+	comparisons []versionComparison
+}
+
+// versionComparison は1つの比較だ
+type versionComparison struct {
+	op      string
+	version string
+}
+
+// versionOperators は使える比較演算子だ。長いものから照合する
+var versionOperators = []string{">=", "<=", "!=", ">", "<", "="}
+
+// ParseVersionConstraint はバージョン制約を解析する
+// 演算子を省略した場合は=とみなす
+func ParseVersionConstraint(s string) (VersionConstraint, error) {
+	c := VersionConstraint{raw: s}
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			return VersionConstraint{}, fmt.Errorf("invalid version constraint %q: empty comparison", s)
+		}
+
+		op := "="
+		for _, candidate := range versionOperators {
+			if rest, ok := strings.CutPrefix(part, candidate); ok {
+				op = candidate
+				part = strings.TrimSpace(rest)
+				break
+			}
+		}
+		if !semver.IsValid(part) {
+			return VersionConstraint{}, fmt.Errorf("invalid version constraint %q: %q is not a semantic version", s, part)
+		}
+		c.comparisons = append(c.comparisons, versionComparison{op: op, version: part})
+	}
+	return c, nil
+}
+
+// Allows はバージョンが制約を満たすか確認する
+// 疑似バージョンもセマンティックバージョンとして比較する
+func (c VersionConstraint) Allows(version string) bool {
+	for _, cmp := range c.comparisons {
+		r := semver.Compare(version, cmp.version)
+		var ok bool
+		switch cmp.op {
+		case "=":
+			ok = r == 0
+		case "!=":
+			ok = r != 0
+		case ">":
+			ok = r > 0
+		case ">=":
+			ok = r >= 0
+		case "<":
+			ok = r < 0
+		case "<=":
+			ok = r <= 0
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func (c VersionConstraint) String() string {
+	return c.raw
+}
@@ -0,0 +1,79 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/blck-snwmn/dependencylintgo/analyzer/config"
+)
+
+func TestVersionConstraint(t *testing.T) {
+	tests := []struct {
+		name       string
+		constraint string
+		version    string
+		want       bool
+	}{
+		{
+			name:       "下限を満たす",
+			constraint: ">= v1.8.0",
+			version:    "v1.8.0",
+			want:       true,
+		},
+		{
+			name:       "下限を満たさない",
+			constraint: ">= v1.8.0",
+			version:    "v1.7.9",
+			want:       false,
+		},
+		{
+			name:       "範囲内",
+			constraint: ">= v1.8.0, < v2",
+			version:    "v1.9.1",
+			want:       true,
+		},
+		{
+			name:       "範囲外",
+			constraint: ">= v1.8.0, < v2",
+			version:    "v2.0.0+incompatible",
+			want:       false,
+		},
+		{
+			name:       "演算子の省略は完全一致",
+			constraint: "v1.2.3",
+			version:    "v1.2.3",
+			want:       true,
+		},
+		{
+			name:       "除外",
+			constraint: "!= v1.2.3",
+			version:    "v1.2.3",
+			want:       false,
+		},
+		{
+			name:       "疑似バージョン",
+			constraint: "> v1.2.3",
+			version:    "v1.2.4-0.20240101000000-abcdefabcdef",
+			want:       true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := config.ParseVersionConstraint(tt.constraint)
+			if err != nil {
+				t.Fatalf("ParseVersionConstraint() error = %v", err)
+			}
+			if got := c.Allows(tt.version); got != tt.want {
+				t.Errorf("Allows(%q) = %v, want %v", tt.version, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseVersionConstraintInvalid(t *testing.T) {
+	for _, constraint := range []string{"", ">= 1.8.0", ">= v1.8.0,", "~> v1.2"} {
+		if _, err := config.ParseVersionConstraint(constraint); err == nil {
+			t.Errorf("ParseVersionConstraint(%q) expected error", constraint)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+// CurrentVersion は最新の設定ファイルの形式のバージョンだ
+// ルールの意味が変わる変更をするときはバージョンを上げ、
+// versionDecodersとmigrationsに古いバージョンの扱いを追加する
+const CurrentVersion = 1
+
+// versionDecoders はバージョンごとの設定ファイルのデコーダだ
+var versionDecoders = map[int]func(node *yaml.Node, config *Config) error{
+	1: decodeV1,
+}
+
+// migrations[v] はバージョンvの設定ファイルをv+1の形式に書き換える
+var migrations = map[int]func(root *yaml.Node) error{}
+
+// decodeVersioned はversionキーに応じたデコーダで設定を読み込む
+func decodeVersioned(node *yaml.Node, config *Config) error {
+	version, _, err := configVersion(node)
+	if err != nil {
+		return err
+	}
+
+	decode, ok := versionDecoders[version]
+	if !ok {
+		if version > CurrentVersion {
+			return fmt.Errorf("unsupported config version %d (this llinter supports up to %d; please upgrade llinter)", version, CurrentVersion)
+		}
+		return fmt.Errorf("unsupported config version %d", version)
+	}
+	return decode(node, config)
+}
+
+// configVersion は設定のversionキーの値を返す
+// versionキーがない設定ファイルはバージョンを導入する前の形式で、バージョン1として扱う
+func configVersion(node *yaml.Node) (version int, explicit bool, err error) {
+	if node.Kind != yaml.MappingNode {
+		return 0, false, errors.New("config must be a mapping")
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value != "version" {
+			continue
+		}
+		version, err := strconv.Atoi(node.Content[i+1].Value)
+		if err != nil || version < 1 {
+			return 0, false, fmt.Errorf("invalid config version %q", node.Content[i+1].Value)
+		}
+		return version, true, nil
+	}
+	return 1, false, nil
+}
+
+func decodeV1(node *yaml.Node, config *Config) error {
+	return node.Decode(config)
+}
+
+// Migrate はYAMLの設定ファイルを最新の形式に書き換える
+// コメントを残すため、構造体ではなくYAMLのノードを書き換える
+func Migrate(data []byte) ([]byte, error) {
+	return migrateTo(data, CurrentVersion)
+}
+
+// migrateTo はYAMLの設定ファイルをtargetのバージョンの形式に書き換える
+func migrateTo(data []byte, target int) ([]byte, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	if len(doc.Content) == 0 {
+		return nil, errors.New("config is empty")
+	}
+	root := doc.Content[0]
+
+	version, explicit, err := configVersion(root)
+	if err != nil {
+		return nil, err
+	}
+	if version > target {
+		return nil, fmt.Errorf("unsupported config version %d (this llinter supports up to %d)", version, target)
+	}
+	if version == target && explicit {
+		return data, nil
+	}
+
+	for v := version; v < target; v++ {
+		migrate, ok := migrations[v]
+		if !ok {
+			return nil, fmt.Errorf("no migration from config version %d", v)
+		}
+		if err := migrate(root); err != nil {
+			return nil, fmt.Errorf("migrating from config version %d: %w", v, err)
+		}
+	}
+	setVersion(root, target)
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// setVersion はversionキーを設定する。キーがなければ先頭に追加する
+func setVersion(root *yaml.Node, version int) {
+	value := strconv.Itoa(version)
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value == "version" {
+			root.Content[i+1].Value = value
+			root.Content[i+1].Tag = "!!int"
+			return
+		}
+	}
+
+	key := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "version"}
+	val := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: value}
+	// 先頭のキーに付いているコメントはファイル全体の説明であることが多いので先頭に残す
+	if len(root.Content) > 0 {
+		key.HeadComment = root.Content[0].HeadComment
+		root.Content[0].HeadComment = ""
+	}
+	root.Content = append([]*yaml.Node{key, val}, root.Content...)
+}
@@ -0,0 +1,148 @@
+package config_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/blck-snwmn/dependencylintgo/analyzer/config"
+	"gopkg.in/yaml.v3"
+)
+
+func TestLoadConfigVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{
+			name:    "バージョンなし",
+			content: "rules: []\n",
+		},
+		{
+			name:    "最新のバージョン",
+			content: "version: 1\nrules: []\n",
+		},
+		{
+			name:    "未対応の新しいバージョン",
+			content: "version: 99\nrules: []\n",
+			wantErr: "please upgrade llinter",
+		},
+		{
+			name:    "不正なバージョン",
+			content: "version: latest\nrules: []\n",
+			wantErr: "invalid config version",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), ".llinter.yaml")
+			if err := os.WriteFile(configPath, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("Failed to write test config file: %v", err)
+			}
+
+			_, err := config.LoadConfig(configPath)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Failed to load config: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	input := `# 組織共通のルール
+rules:
+  - path: ["internal/**/*.go"] # 内部パッケージ
+    deny:
+      - "fmt" # fmtは禁止
+`
+	got, err := config.Migrate([]byte(input))
+	if err != nil {
+		t.Fatalf("Failed to migrate: %v", err)
+	}
+
+	want := `# 組織共通のルール
+version: 1
+rules:
+  - path: ["internal/**/*.go"] # 内部パッケージ
+    deny:
+      - "fmt" # fmtは禁止
+`
+	if string(got) != want {
+		t.Errorf("Migrate() =\n%s\nwant\n%s", got, want)
+	}
+
+	// 最新の形式ならそのまま返す
+	again, err := config.Migrate(got)
+	if err != nil {
+		t.Fatalf("Failed to migrate: %v", err)
+	}
+	if string(again) != string(got) {
+		t.Errorf("Migrate() changed up-to-date config:\n%s", again)
+	}
+
+	if _, err := config.Migrate([]byte("version: 99\n")); err == nil {
+		t.Error("Expected error for unsupported version, got nil")
+	}
+}
+
+func TestMigrateRegistered(t *testing.T) {
+	// バージョン1から2で、ルールのdenyをforbidに改名したとする
+	restore := config.SetMigration(1, func(root *yaml.Node) error {
+		for i := 0; i+1 < len(root.Content); i += 2 {
+			if root.Content[i].Value != "rules" {
+				continue
+			}
+			for _, rule := range root.Content[i+1].Content {
+				for j := 0; j+1 < len(rule.Content); j += 2 {
+					if rule.Content[j].Value == "deny" {
+						rule.Content[j].Value = "forbid"
+					}
+				}
+			}
+		}
+		return nil
+	})
+	t.Cleanup(restore)
+
+	input := `version: 1
+rules:
+  - path: ["*.go"]
+    deny: ["fmt"] # fmtは禁止
+`
+	got, err := config.MigrateTo([]byte(input), 2)
+	if err != nil {
+		t.Fatalf("Failed to migrate: %v", err)
+	}
+
+	want := `version: 2
+rules:
+  - path: ["*.go"]
+    forbid: ["fmt"] # fmtは禁止
+`
+	if string(got) != want {
+		t.Errorf("MigrateTo() =\n%s\nwant\n%s", got, want)
+	}
+
+	// 途中のmigrationがなければエラー
+	if _, err := config.MigrateTo([]byte(input), 3); err == nil || !strings.Contains(err.Error(), "no migration from config version 2") {
+		t.Errorf("Expected missing migration error, got %v", err)
+	}
+
+	// migrationの失敗はバージョンを付けて返す
+	t.Cleanup(config.SetMigration(1, func(root *yaml.Node) error {
+		return errors.New("broken")
+	}))
+	if _, err := config.MigrateTo([]byte(input), 2); err == nil || !strings.Contains(err.Error(), "migrating from config version 1: broken") {
+		t.Errorf("Expected migration error, got %v", err)
+	}
+}
@@ -0,0 +1,76 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+
+	"golang.org/x/mod/modfile"
+)
+
+// Workspace はgo.workで構成されたマルチモジュールのワークスペースだ
+type Workspace struct {
+	Dir     string   // go.workのあるディレクトリ
+	Modules []string // useで指定されたモジュールのルート（絶対パス）
+}
+
+// FindWorkspace はdirから親ディレクトリへ遡ってgo.workを探す
+// goコマンドと同じく環境変数GOWORKがあればそれを使い、offならワークスペースを使わない
+// 見つからなければnilを返す
+func FindWorkspace(dir string) (*Workspace, error) {
+	gowork := os.Getenv("GOWORK")
+	switch gowork {
+	case "off":
+		return nil, nil
+	case "":
+		dir, err := filepath.Abs(dir)
+		if err != nil {
+			return nil, err
+		}
+		for {
+			candidate := filepath.Join(dir, "go.work")
+			if _, err := os.Stat(candidate); err == nil {
+				gowork = candidate
+				break
+			}
+			parent := filepath.Dir(dir)
+			if parent == dir {
+				return nil, nil
+			}
+			dir = parent
+		}
+	}
+
+	data, err := os.ReadFile(gowork)
+	if err != nil {
+		return nil, err
+	}
+	f, err := modfile.ParseWork(gowork, data, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	ws := &Workspace{Dir: filepath.Dir(gowork)}
+	for _, use := range f.Use {
+		dir := filepath.FromSlash(use.Path)
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(ws.Dir, dir)
+		}
+		ws.Modules = append(ws.Modules, filepath.Clean(dir))
+	}
+	return ws, nil
+}
+
+// Contains はモジュールのルートがワークスペースで使われているか確認する
+func (w *Workspace) Contains(moduleRoot string) bool {
+	return w != nil && slices.Contains(w.Modules, filepath.Clean(moduleRoot))
+}
+
+// moduleWorkspace はモジュールのルートを使っているワークスペースを返す。なければnilを返す
+func moduleWorkspace(moduleRoot string) (*Workspace, error) {
+	ws, err := FindWorkspace(moduleRoot)
+	if err != nil || !ws.Contains(moduleRoot) {
+		return nil, err
+	}
+	return ws, nil
+}
@@ -0,0 +1,157 @@
+package config_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/blck-snwmn/dependencylintgo/analyzer/config"
+)
+
+func TestFindWorkspace(t *testing.T) {
+	tempDir := t.TempDir()
+	writeFiles(t, tempDir, map[string]string{
+		"ws/go.work":        "go 1.24\n\nuse (\n\t./a\n\t./libs/b\n)\n",
+		"ws/a/go.mod":       "module example.com/a\n",
+		"ws/a/pkg/.keep":    "",
+		"ws/libs/b/go.mod":  "module example.com/b\n",
+		"single/go.mod":     "module example.com/single\n",
+		"single/pkg/.keep":  "",
+		"custom/other.work": "go 1.24\n\nuse ./c\n",
+	})
+	t.Setenv("GOWORK", "")
+
+	t.Run("親ディレクトリのgo.work", func(t *testing.T) {
+		ws, err := config.FindWorkspace(filepath.Join(tempDir, "ws/a/pkg"))
+		if err != nil || ws == nil {
+			t.Fatalf("FindWorkspace() = %v, %v", ws, err)
+		}
+		if ws.Dir != filepath.Join(tempDir, "ws") {
+			t.Errorf("Unexpected dir: %s", ws.Dir)
+		}
+		if !ws.Contains(filepath.Join(tempDir, "ws/libs/b")) || ws.Contains(filepath.Join(tempDir, "single")) {
+			t.Errorf("Unexpected modules: %v", ws.Modules)
+		}
+	})
+
+	t.Run("go.workがない", func(t *testing.T) {
+		ws, err := config.FindWorkspace(filepath.Join(tempDir, "single/pkg"))
+		if err != nil || ws != nil {
+			t.Errorf("FindWorkspace() = %v, %v, want nil", ws, err)
+		}
+	})
+
+	t.Run("GOWORKで指定する", func(t *testing.T) {
+		t.Setenv("GOWORK", filepath.Join(tempDir, "custom/other.work"))
+		ws, err := config.FindWorkspace(filepath.Join(tempDir, "ws/a"))
+		if err != nil || ws == nil {
+			t.Fatalf("FindWorkspace() = %v, %v", ws, err)
+		}
+		if !ws.Contains(filepath.Join(tempDir, "custom/c")) {
+			t.Errorf("Unexpected modules: %v", ws.Modules)
+		}
+	})
+
+	t.Run("GOWORK=off", func(t *testing.T) {
+		t.Setenv("GOWORK", "off")
+		ws, err := config.FindWorkspace(filepath.Join(tempDir, "ws/a"))
+		if err != nil || ws != nil {
+			t.Errorf("FindWorkspace() = %v, %v, want nil", ws, err)
+		}
+	})
+}
+
+func TestResolveConfigPathFor(t *testing.T) {
+	tempDir := t.TempDir()
+	writeFiles(t, tempDir, map[string]string{
+		"ws/go.work":            "go 1.24\n\nuse (\n\t./a\n\t./b\n)\n",
+		"ws/.llinter.yaml":      "rules: []\n",
+		"ws/a/go.mod":           "module example.com/a\n",
+		"ws/a/.llinter.yaml":    "rules: []\n",
+		"ws/a/pkg/.keep":        "",
+		"ws/b/go.mod":           "module example.com/b\n",
+		"ws/b/pkg/.keep":        "",
+		"repo/go.mod":           "module example.com/repo\n",
+		"repo/.llinter.yaml":    "rules: []\n",
+		"repo/sub/.keep":        "",
+		"repo/sub/.llinter.yml": "rules: []\n",
+	})
+	t.Setenv("GOWORK", "")
+
+	tests := []struct {
+		name string
+		cwd  string
+		dir  string
+		want string
+	}{
+		{
+			name: "ワークスペースのモジュールの設定ファイルを使う",
+			cwd:  "ws",
+			dir:  "ws/a/pkg",
+			want: "ws/a/.llinter.yaml",
+		},
+		{
+			name: "モジュールに設定ファイルがなければ作業ディレクトリから探す",
+			cwd:  "ws",
+			dir:  "ws/b/pkg",
+			want: "ws/.llinter.yaml",
+		},
+		{
+			name: "ワークスペースでなければ作業ディレクトリから探す",
+			cwd:  "repo/sub",
+			dir:  "repo",
+			want: "repo/sub/.llinter.yml",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Chdir(filepath.Join(tempDir, tt.cwd))
+
+			got, err := config.ResolveConfigPathFor(config.DefaultConfigName, filepath.Join(tempDir, tt.dir))
+			if err != nil {
+				t.Fatalf("ResolveConfigPathFor() error = %v", err)
+			}
+			if got != filepath.Join(tempDir, tt.want) {
+				t.Errorf("ResolveConfigPathFor() = %q, want %q", got, filepath.Join(tempDir, tt.want))
+			}
+		})
+	}
+}
+
+func TestMatchPath(t *testing.T) {
+	tests := []struct {
+		name     string
+		filePath string
+		pkgPath  string
+		root     string
+		want     string
+	}{
+		{
+			name:     "モジュールルートからの相対パス",
+			filePath: "/home/user/src/app/internal/api/api.go",
+			pkgPath:  "example.com/app/internal/api",
+			root:     "/home/user/src/app",
+			want:     "internal/api/api.go",
+		},
+		{
+			name:     "GOPATHモードではimportパス",
+			filePath: "/go/src/example/sub/main.go",
+			pkgPath:  "example/sub",
+			want:     "example/sub/main.go",
+		},
+		{
+			name:     "GOPATHモードの外部テストパッケージ",
+			filePath: "/go/src/example/sub/main_test.go",
+			pkgPath:  "example/sub_test",
+			want:     "example/sub/main_test.go",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := config.MatchPath(filepath.FromSlash(tt.filePath), tt.pkgPath, filepath.FromSlash(tt.root)); got != tt.want {
+				t.Errorf("MatchPath() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
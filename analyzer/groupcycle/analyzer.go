@@ -0,0 +1,307 @@
+package groupcycle
+
+import (
+	"errors"
+	"go/build"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/blck-snwmn/dependencylintgo/analyzer/config"
+	"golang.org/x/tools/go/analysis"
+)
+
+var configFile string
+
+// Analyzer は設定されたグループ間の循環依存をチェックするanalyzerだ
+var Analyzer = &analysis.Analyzer{
+	Name: "groupcycle",
+	Doc:  "checks for import cycles between configured package groups",
+	Run:  run,
+}
+
+func init() {
+	Analyzer.Flags.StringVar(&configFile, "config", ".llinter.yaml", "configuration file path")
+}
+
+// Edge はグループ間のimport辺だ
+// From/To はグループ名、FromPkg/ToPkg はその辺を生んだパッケージ
+// グループに属さないパッケージを経由する場合、Viaに経由したパッケージが順に入る
+type Edge struct {
+	From    string
+	To      string
+	FromPkg string
+	ToPkg   string
+	Via     []string
+}
+
+func (e Edge) String() string {
+	pkgs := append(append([]string{e.FromPkg}, e.Via...), e.ToPkg)
+	return strings.Join(pkgs, " -> ")
+}
+
+// Reach はimportから、グループに属さないパッケージだけを経由して到達できるグループだ
+// Pkg は到達したグループのパッケージ、Via は途中で経由したパッケージ
+type Reach struct {
+	Group string
+	Pkg   string
+	Via   []string
+}
+
+// groupGraph はモジュール全体のパッケージのimportと、そこから求めたグループ間の辺だ
+type groupGraph struct {
+	imports map[string][]string        // パッケージのimportパスからそのimport
+	edges   map[string]map[string]Edge // グループ間の辺。同じグループの組は最初に見つけた辺で代表する
+}
+
+// graphs は解析するルートと設定ファイルごとのgroupGraphだ
+// 兄弟パッケージの間の循環も見つけるため、解析するパッケージの依存だけでなくモジュール全体から一度だけ作る
+var graphs sync.Map
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	if len(pass.Files) == 0 {
+		return nil, nil
+	}
+	dir := filepath.Dir(pass.Fset.Position(pass.Files[0].Package).Filename)
+
+	// 走査するルートとそのimportパス（GOPATHモードではsrc以下の最上位のディレクトリ）
+	root, rootPath, ok := packageRoot(pass, dir)
+	if !ok {
+		return nil, nil
+	}
+
+	// 設定ファイルの読み込み（go.workのワークスペースではモジュールごとの設定ファイルを優先する）
+	var cfg *config.Config
+	configPath, err := config.ResolveConfigPathFor(configFile, dir)
+	if err == nil {
+		cfg, err = config.LoadConfig(configPath)
+	}
+	if err != nil {
+		// 設定ファイルが見つからない場合はスキップする
+		if config.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if len(cfg.Groups) == 0 {
+		return nil, nil
+	}
+	group := config.FindGroup(cfg, pass.Pkg.Path())
+	if group == nil {
+		return nil, nil
+	}
+
+	key := root + "\x00" + configPath
+	v, ok := graphs.Load(key)
+	if !ok {
+		g, err := loadGroupGraph(cfg, root, rootPath)
+		if err != nil {
+			return nil, err
+		}
+		v, _ = graphs.LoadOrStore(key, g)
+	}
+	graph := v.(*groupGraph)
+
+	// このパッケージのimportが生む辺で循環が閉じる場合に報告する
+	reported := make(map[string]bool)
+	for _, file := range pass.Files {
+		for _, spec := range file.Imports {
+			importPath := strings.Trim(spec.Path.Value, "\"")
+			for _, r := range graph.reach(cfg, importPath) {
+				if r.Group == group.Name || reported[r.Group] {
+					continue
+				}
+				path := findPath(graph.edges, r.Group, group.Name)
+				if path == nil {
+					continue
+				}
+				reported[r.Group] = true
+
+				e := Edge{From: group.Name, To: r.Group, FromPkg: pass.Pkg.Path(), ToPkg: r.Pkg, Via: r.Via}
+				cycle := append([]Edge{e}, path...)
+				groups := []string{e.From}
+				details := make([]string, 0, len(cycle))
+				for _, e := range cycle {
+					groups = append(groups, e.To)
+					details = append(details, e.String())
+				}
+				pass.Reportf(spec.Pos(), "import %q creates a cycle between groups: %s (%s)",
+					importPath, strings.Join(groups, " -> "), strings.Join(details, ", "))
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+// packageRoot はグループ間の辺を求めるために走査するディレクトリと、そのimportパスを返す
+// モジュールではモジュールルート、GOPATHモードではGOPATHのsrc以下でパッケージを含む最上位のディレクトリになる
+// vendorディレクトリの依存パッケージなど、解析中のモジュールに属さないパッケージではfalseを返す
+func packageRoot(pass *analysis.Pass, dir string) (string, string, bool) {
+	pkgPath := strings.TrimSuffix(pass.Pkg.Path(), "_test")
+	if pass.Module != nil && pass.Module.Path != "" {
+		moduleRoot, modulePath, err := config.FindModule(dir)
+		if err != nil || modulePath != pass.Module.Path {
+			return "", "", false
+		}
+		return moduleRoot, modulePath, true
+	}
+
+	src, ok := strings.CutSuffix(filepath.ToSlash(dir), "/"+pkgPath)
+	if !ok {
+		return "", "", false
+	}
+	top, _, _ := strings.Cut(pkgPath, "/")
+	return filepath.Join(filepath.FromSlash(src), top), top, true
+}
+
+// loadGroupGraph はroot以下のパッケージのimportを読み込み、グループ間の辺を求める
+// ビルド制約は実行環境のGOOS/GOARCHで判定し、テストファイルのimportも含める
+// vendor、testdata、_や.で始まるディレクトリと、別のモジュール（go.modのあるディレクトリ）は読まない
+func loadGroupGraph(cfg *config.Config, root, rootPath string) (*groupGraph, error) {
+	g := &groupGraph{
+		imports: make(map[string][]string),
+		edges:   make(map[string]map[string]Edge),
+	}
+
+	err := filepath.WalkDir(root, func(dir string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if dir != root {
+			name := d.Name()
+			if name == "vendor" || name == "testdata" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") {
+				return filepath.SkipDir
+			}
+			if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+				return filepath.SkipDir
+			}
+		}
+
+		p, err := build.Default.ImportDir(dir, build.ImportComment)
+		if err != nil {
+			var noGo *build.NoGoError
+			if errors.As(err, &noGo) {
+				return nil
+			}
+		}
+		rel, err := filepath.Rel(root, dir)
+		if err != nil {
+			return err
+		}
+		imports := slices.Concat(p.Imports, p.TestImports, p.XTestImports)
+		sort.Strings(imports)
+		g.imports[path.Join(rootPath, filepath.ToSlash(rel))] = slices.Compact(imports)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	pkgs := make([]string, 0, len(g.imports))
+	for pkg := range g.imports {
+		pkgs = append(pkgs, pkg)
+	}
+	sort.Strings(pkgs)
+	for _, pkg := range pkgs {
+		group := config.FindGroup(cfg, pkg)
+		if group == nil {
+			continue
+		}
+		for _, importPath := range g.imports[pkg] {
+			for _, r := range g.reach(cfg, importPath) {
+				if r.Group == group.Name {
+					continue
+				}
+				if g.edges[group.Name] == nil {
+					g.edges[group.Name] = make(map[string]Edge)
+				}
+				if _, ok := g.edges[group.Name][r.Group]; !ok {
+					g.edges[group.Name][r.Group] = Edge{From: group.Name, To: r.Group, FromPkg: pkg, ToPkg: r.Pkg, Via: r.Via}
+				}
+			}
+		}
+	}
+	return g, nil
+}
+
+// reach はimportPathから、グループに属さないパッケージだけを経由して到達できるグループを返す
+// 同じグループに複数の経路で到達する場合は、経由するパッケージの少ないものを使う
+func (g *groupGraph) reach(cfg *config.Config, importPath string) []Reach {
+	type step struct {
+		pkg string
+		via []string
+	}
+
+	var reach []Reach
+	seenGroups := make(map[string]bool)
+	visited := map[string]bool{importPath: true}
+	queue := []step{{pkg: importPath}}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+
+		if group := config.FindGroup(cfg, cur.pkg); group != nil {
+			if !seenGroups[group.Name] {
+				seenGroups[group.Name] = true
+				reach = append(reach, Reach{Group: group.Name, Pkg: cur.pkg, Via: cur.via})
+			}
+			continue
+		}
+
+		// 幅優先で探索するので、先に見つかった経路ほど経由するパッケージが少ない
+		for _, next := range g.imports[cur.pkg] {
+			if visited[next] {
+				continue
+			}
+			visited[next] = true
+			queue = append(queue, step{pkg: next, via: append(slices.Clone(cur.via), cur.pkg)})
+		}
+	}
+
+	sort.Slice(reach, func(i, j int) bool { return reach[i].Group < reach[j].Group })
+	return reach
+}
+
+// findPath はfromからtoへの最短経路を幅優先探索で求める
+func findPath(graph map[string]map[string]Edge, from, to string) []Edge {
+	prev := map[string]Edge{}
+	visited := map[string]bool{from: true}
+	queue := []string{from}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		if cur == to {
+			var path []Edge
+			for cur != from {
+				e := prev[cur]
+				path = append([]Edge{e}, path...)
+				cur = e.From
+			}
+			return path
+		}
+
+		next := make([]string, 0, len(graph[cur]))
+		for name := range graph[cur] {
+			next = append(next, name)
+		}
+		sort.Strings(next)
+		for _, name := range next {
+			if visited[name] {
+				continue
+			}
+			visited[name] = true
+			prev[name] = graph[cur][name]
+			queue = append(queue, name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package groupcycle_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/blck-snwmn/dependencylintgo/analyzer/groupcycle"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+// TestAnalyzer はグループ間の循環依存を検出できるかテストする
+func TestAnalyzer(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get working directory: %v", err)
+	}
+
+	// analyzer/groupcycleからプロジェクトルートへ移動
+	testdata := filepath.Join(wd, "../..", "testdata")
+
+	// 設定ファイルのパスをAnalyzerに直接設定
+	groupcycle.Analyzer.Flags.Set("config", filepath.Join(testdata, ".llinter.yaml"))
+
+	analysistest.Run(t, testdata, groupcycle.Analyzer, "cycle/...")
+}
+
+// TestWorkspace はgo.workのワークスペースで、モジュールごとの設定ファイルのグループを使うかテストする
+func TestWorkspace(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get working directory: %v", err)
+	}
+	dir := filepath.Join(wd, "../..", "testdata", "groupcycle")
+	// ワークスペースモードでは-mod=modを使えないので、環境のGOFLAGSを引き継がない
+	t.Setenv("GOFLAGS", "")
+
+	groupcycle.Analyzer.Flags.Set("config", ".llinter.yaml")
+	t.Cleanup(func() { groupcycle.Analyzer.Flags.Set("config", ".llinter.yaml") })
+
+	analysistest.Run(t, dir, groupcycle.Analyzer, "example.com/app/...")
+}
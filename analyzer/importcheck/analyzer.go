@@ -1,16 +1,38 @@
 package importcheck
 
 import (
+	"fmt"
 	"go/ast"
+	"go/token"
+	"go/types"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
 	"strings"
+	"sync"
 
 	"github.com/blck-snwmn/dependencylintgo/analyzer/config"
+	"github.com/blck-snwmn/dependencylintgo/analyzer/policy"
 	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/passes/inspect"
 	"golang.org/x/tools/go/ast/inspector"
 )
 
-var configFile string
+var (
+	configFile   string
+	hierarchical bool
+	verbose      bool
+
+	// today は非推奨のimportの期限と比べる日付だ
+	today = config.Today
+
+	// 使用した設定ファイルの表示は設定ファイルごとに1回だけ行う
+	reportedConfigs sync.Map // map[string]bool
+
+	// stderr は使用した設定ファイルの表示先だ
+	stderr io.Writer = os.Stderr
+)
 
 // Analyzer はimportチェック用のanalyzerだ
 var Analyzer = &analysis.Analyzer{
@@ -24,65 +46,315 @@ var Analyzer = &analysis.Analyzer{
 
 func init() {
 	Analyzer.Flags.StringVar(&configFile, "config", ".llinter.yaml", "configuration file path")
+	Analyzer.Flags.BoolVar(&verbose, "verbose", false, "report which configuration file is used")
+	Analyzer.Flags.BoolVar(&hierarchical, "hierarchical", false, "discover .llinter.yaml files from the module root down to each package directory")
 }
 
 func run(pass *analysis.Pass) (interface{}, error) {
 	inspector := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
 
 	// 設定ファイルの読み込み
-	cfg, err := config.LoadConfig(configFile)
+	cfg, root, err := loadConfig(pass)
 	if err != nil {
 		// 設定ファイルが見つからない場合はスキップする
-		if strings.Contains(err.Error(), "no such file") {
+		if config.IsNotFound(err) {
 			return nil, nil
 		}
 		return nil, err
 	}
+	if cfg == nil {
+		return nil, nil
+	}
+	pol := policy.New(cfg)
 
 	nodeFilter := []ast.Node{
+		(*ast.File)(nil),
 		(*ast.ImportSpec)(nil),
+		(*ast.Ident)(nil),
 	}
 
+	// Preorderはファイルをその子ノードより先に訪れるので、ファイル単位でルールを決める
+	var (
+		file    config.File
+		rule    *policy.Rule
+		budgets packageBudgets
+	)
 	inspector.Preorder(nodeFilter, func(n ast.Node) {
-		importSpec := n.(*ast.ImportSpec)
-		importPath := strings.Trim(importSpec.Path.Value, "\"")
-
-		// ファイルパスを取得
-		filePath := pass.Fset.Position(n.Pos()).Filename
+		switch n := n.(type) {
+		case *ast.File:
+			// ファイルパスを取得
+			filePath := pass.Fset.Position(n.Pos()).Filename
 
-		// ファイルパスを処理
-		relPath := filePath
-
-		// テスト環境では、ファイルパスにtestdata/src/が含まれる
-		if strings.Contains(filePath, "testdata/src/") {
-			// testdata/src/ 以降のパスを抽出
-			parts := strings.Split(filePath, "testdata/src/")
-			if len(parts) > 1 {
-				relPath = parts[1]
+			// ルールを検索
+			file = config.NewFile(config.MatchPath(filePath, pass.Pkg.Path(), root), n)
+			rule = pol.RuleFor(file)
+			if rule != nil {
+				checkFileBudget(pass, rule, n)
+				if countsForPackageBudget(rule, file) {
+					budgets.add(rule, n)
+				}
+			}
+		case *ast.ImportSpec:
+			// 非推奨のimportはルールに関係なくチェックする
+			checkImport(pass, pol, file, n)
+			if rule == nil {
+				return // マッチするルールがなければチェックしない
 			}
-		} else if strings.Contains(filePath, "/src/") {
-			// 通常の Go パッケージの場合は /src/ 以降を抽出
-			parts := strings.Split(filePath, "/src/")
-			if len(parts) > 1 {
-				relPath = "src/" + parts[1]
+			checkImportName(pass, rule, n)
+		case *ast.Ident:
+			if rule == nil || len(rule.Config.DenySymbols) == 0 {
+				return
 			}
+			checkSymbol(pass, rule, n)
 		}
+	})
+	budgets.check(pass)
 
-		// ルールを検索
-		rule := config.FindMatchingRule(cfg, relPath)
-		if rule == nil {
-			return // マッチするルールがなければチェックしない
+	return nil, nil
+}
+
+// loadConfig はパッケージに適用する設定と、ファイルパスの基準になるディレクトリを返す
+// 基準ディレクトリはファイルを含むモジュールのルートで、GOPATHモードでは空になる
+// vendorディレクトリの依存パッケージなど、解析中のモジュールに属さないパッケージにはnilを返す
+func loadConfig(pass *analysis.Pass) (*config.Config, string, error) {
+	if len(pass.Files) == 0 {
+		return nil, "", nil
+	}
+	dir := filepath.Dir(pass.Fset.Position(pass.Files[0].Package).Filename)
+
+	// GOPATHモードではモジュールのパスが空になる
+	root := ""
+	if pass.Module != nil && pass.Module.Path != "" {
+		moduleRoot, modulePath, err := config.FindModule(dir)
+		if err != nil || modulePath != pass.Module.Path {
+			return nil, "", nil
 		}
+		root = moduleRoot
+	}
 
-		// denyリストに含まれているかチェック
-		if config.IsImportPathMatched(importPath, rule.Deny) {
-			// allowリストで明示的に許可されているか確認
-			if !config.IsImportPathMatched(importPath, rule.Allow) {
-				// エラー報告
-				pass.Reportf(importSpec.Pos(), "import %q is not allowed in this file based on configuration", importPath)
+	if hierarchical {
+		cfg, root, paths, err := config.DiscoverFiles(dir)
+		if verbose && err == nil {
+			if len(paths) == 0 {
+				reportNoConfig(dir)
+			}
+			for _, path := range paths {
+				reportConfig(path, nil)
 			}
 		}
+		return cfg, root, err
+	}
+
+	path, err := config.ResolveConfigPathFor(configFile, dir)
+	if verbose {
+		reportConfig(path, err)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	cfg, err := config.LoadConfig(path)
+	return cfg, root, err
+}
+
+// reportConfig は使用した設定ファイルを表示する
+// go.workのワークスペースや-hierarchicalではパッケージごとに設定ファイルが変わるので、ファイルごとに1回だけ表示する
+func reportConfig(path string, err error) {
+	if _, reported := reportedConfigs.LoadOrStore(path, true); reported {
+		return
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "llinter: no configuration file found for %s\n", configFile)
+		return
+	}
+	fmt.Fprintf(stderr, "llinter: using configuration file %s\n", path)
+}
+
+// reportNoConfig は-hierarchicalでディレクトリから設定ファイルが見つからなかったことを表示する
+func reportNoConfig(dir string) {
+	if _, reported := reportedConfigs.LoadOrStore(dir, true); reported {
+		return
+	}
+	fmt.Fprintf(stderr, "llinter: no configuration file found for %s\n", dir)
+}
+
+// report はルールの重大度を付けて診断を報告する
+func report(pass *analysis.Pass, rule *policy.Rule, diag analysis.Diagnostic) {
+	reportSeverity(pass, rule.Severity(), diag)
+}
+
+// WarningPrefix は警告の診断のメッセージの先頭に付ける文字列だ
+const WarningPrefix = "[warning] "
+
+// reportSeverity は重大度を付けて診断を報告する
+// 警告はCategoryに加えてメッセージの先頭にもWarningPrefixを付けて、エラーと見分けられるようにする
+func reportSeverity(pass *analysis.Pass, severity string, diag analysis.Diagnostic) {
+	diag.Category = severity
+	if severity == config.SeverityWarning {
+		diag.Message = WarningPrefix + diag.Message
+	}
+	pass.Report(diag)
+}
+
+// reportf はルールの重大度を付けて位置posの診断を報告する
+func reportf(pass *analysis.Pass, rule *policy.Rule, pos token.Pos, format string, args ...any) {
+	report(pass, rule, analysis.Diagnostic{Pos: pos, Message: fmt.Sprintf(format, args...)})
+}
+
+// checkImport はimport文がルールで禁止されていないか、非推奨になっていないか確認する
+// 判定はpolicyに任せ、期限内の例外（exceptions）に一致すれば違反を報告しない。期限切れの例外なら違反に加えて期限切れを報告する
+func checkImport(pass *analysis.Pass, pol *policy.Policy, file config.File, importSpec *ast.ImportSpec) {
+	importPath := strings.Trim(importSpec.Path.Value, "\"")
+	d := pol.CheckAt(file, pass.Pkg.Path(), importPath, today())
+
+	if dep := d.Deprecation; dep != nil {
+		reportDeprecation(pass, importSpec, importPath, dep)
+	}
+
+	// denyリストに含まれ、allowリストで明示的に許可されておらず、期限内の例外もなければ報告する
+	if !d.Denied || (d.Exception != nil && !d.Exception.Expired) {
+		return
+	}
+	reportSeverity(pass, d.Severity, analysis.Diagnostic{
+		Pos:     importSpec.Pos(),
+		Message: fmt.Sprintf("import %q is not allowed in this file based on configuration", importPath),
 	})
+	if e := d.Exception; e != nil {
+		owner := "owner: " + e.Config.Owner
+		if e.Config.Ticket != "" {
+			owner += ", ticket: " + e.Config.Ticket
+		}
+		reportSeverity(pass, d.Severity, analysis.Diagnostic{
+			Pos:     importSpec.Pos(),
+			Message: fmt.Sprintf("exception for import %q expired on %s (%s) based on configuration", importPath, e.Config.Expires, owner),
+		})
+	}
+}
 
-	return nil, nil
+// reportDeprecation は非推奨のimportを報告する
+// 期限（until）までは警告として、期限を過ぎたらエラーとして報告する
+func reportDeprecation(pass *analysis.Pass, importSpec *ast.ImportSpec, importPath string, d *policy.Deprecation) {
+	var msg string
+	if d.Expired {
+		msg = fmt.Sprintf("import %q was deprecated until %s and is no longer allowed based on configuration", importPath, d.Config.Until)
+	} else {
+		msg = fmt.Sprintf("import %q is deprecated and will not be allowed after %s based on configuration", importPath, d.Config.Until)
+	}
+	if d.Config.ReplaceWith != "" {
+		msg += fmt.Sprintf("; use %q instead", d.Config.ReplaceWith)
+	}
+	reportSeverity(pass, d.Severity, analysis.Diagnostic{Pos: importSpec.Pos(), Message: msg})
+}
+
+// checkImportName はimportの別名がルールに従っているか確認する
+func checkImportName(pass *analysis.Pass, rule *policy.Rule, importSpec *ast.ImportSpec) {
+	importPath := strings.Trim(importSpec.Path.Value, "\"")
+
+	name := ""
+	if importSpec.Name != nil {
+		name = importSpec.Name.Name
+	}
+
+	switch name {
+	case ".":
+		if rule.Config.DenyDotImports {
+			reportf(pass, rule, importSpec.Pos(), "dot import of %q is not allowed in this file based on configuration", importPath)
+			return
+		}
+	case "_":
+		if !rule.BlankImportAllowed(importPath) {
+			reportf(pass, rule, importSpec.Pos(), "blank import of %q is not allowed in this file based on configuration", importPath)
+		}
+		// ブランクimportには別名の要件を適用しない
+		return
+	}
+
+	alias, ok := rule.RequiredAlias(importPath)
+	if !ok {
+		return
+	}
+
+	pkgName := importedPkgName(pass, importSpec)
+	if pkgName == nil || pkgName.Name() == alias {
+		return
+	}
+
+	diag := analysis.Diagnostic{
+		Pos:     importSpec.Pos(),
+		End:     importSpec.End(),
+		Message: fmt.Sprintf("import %q must use alias %q based on configuration", importPath, alias),
+	}
+	// ドットimportは使用箇所の修飾が必要になるので修正を提案しない
+	if name != "." {
+		diag.SuggestedFixes = []analysis.SuggestedFix{{
+			Message:   fmt.Sprintf("Rename import to %q", alias),
+			TextEdits: renameEdits(pass, importSpec, pkgName, alias),
+		}}
+	}
+	report(pass, rule, diag)
+}
+
+// importedPkgName はimport文で宣言されたパッケージ名のオブジェクトを返す
+func importedPkgName(pass *analysis.Pass, importSpec *ast.ImportSpec) *types.PkgName {
+	var obj types.Object
+	if importSpec.Name != nil {
+		obj = pass.TypesInfo.Defs[importSpec.Name]
+	} else {
+		obj = pass.TypesInfo.Implicits[importSpec]
+	}
+	pkgName, _ := obj.(*types.PkgName)
+	return pkgName
+}
+
+// renameEdits はimportの別名と、その別名を使っている箇所を書き換える編集を作る
+func renameEdits(pass *analysis.Pass, importSpec *ast.ImportSpec, pkgName *types.PkgName, alias string) []analysis.TextEdit {
+	var edits []analysis.TextEdit
+	if importSpec.Name != nil {
+		edits = append(edits, analysis.TextEdit{
+			Pos:     importSpec.Name.Pos(),
+			End:     importSpec.Name.End(),
+			NewText: []byte(alias),
+		})
+	} else {
+		edits = append(edits, analysis.TextEdit{
+			Pos:     importSpec.Path.Pos(),
+			End:     importSpec.Path.Pos(),
+			NewText: []byte(alias + " "),
+		})
+	}
+
+	var uses []*ast.Ident
+	for ident, obj := range pass.TypesInfo.Uses {
+		if obj == pkgName {
+			uses = append(uses, ident)
+		}
+	}
+	sort.Slice(uses, func(i, j int) bool { return uses[i].Pos() < uses[j].Pos() })
+	for _, ident := range uses {
+		edits = append(edits, analysis.TextEdit{
+			Pos:     ident.Pos(),
+			End:     ident.End(),
+			NewText: []byte(alias),
+		})
+	}
+	return edits
+}
+
+// checkSymbol は識別子が禁止されたシンボルを参照していないか確認する
+// TypesInfo.Usesで解決するので、別名importやドットimportでも検出できる
+func checkSymbol(pass *analysis.Pass, rule *policy.Rule, ident *ast.Ident) {
+	obj := pass.TypesInfo.Uses[ident]
+	if obj == nil || obj.Pkg() == nil || obj.Pkg() == pass.Pkg {
+		return
+	}
+	if _, ok := obj.(*types.PkgName); ok {
+		return
+	}
+	// パッケージレベルのシンボルのみ対象にする
+	if obj.Parent() != obj.Pkg().Scope() {
+		return
+	}
+
+	if rule.SymbolDenied(obj.Pkg().Path(), obj.Name()) {
+		reportf(pass, rule, ident.Pos(), "use of %s.%s is not allowed in this file based on configuration", obj.Pkg().Path(), obj.Name())
+	}
 }
@@ -1,8 +1,10 @@
 package importcheck_test
 
 import (
+	"bytes"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/blck-snwmn/dependencylintgo/analyzer/config"
@@ -10,26 +12,47 @@ import (
 	"golang.org/x/tools/go/analysis/analysistest"
 )
 
-// TestAnalyzer は実際のGoファイルに対してAnalyzerを実行するテスト
-func TestAnalyzer(t *testing.T) {
-	// テストデータのディレクトリを取得
-	// プロジェクトルートからの相対パスで指定
+// setup はテストデータのディレクトリを返し、Analyzerのフラグを設定する
+// -configは指定がなければtestdata/.llinter.yamlにする
+// フラグはパッケージ変数に保持されるので、テストの終わりに元の値へ戻す
+func setup(t *testing.T, flags map[string]string) string {
+	t.Helper()
+
 	wd, err := os.Getwd()
 	if err != nil {
 		t.Fatalf("Failed to get working directory: %v", err)
 	}
-
 	// analyzer/importcheckからプロジェクトルートへ移動
-	rootDir := filepath.Join(wd, "../..")
-	testdata := filepath.Join(rootDir, "testdata")
+	testdata := filepath.Join(wd, "../..", "testdata")
 
-	// テスト実行前に設定ファイルのパスを設定
-	configPath := filepath.Join(testdata, ".llinter.yaml")
+	if _, ok := flags["config"]; !ok {
+		setFlag(t, "config", filepath.Join(testdata, ".llinter.yaml"))
+	}
+	for name, value := range flags {
+		setFlag(t, name, value)
+	}
+	return testdata
+}
 
-	// 設定ファイルのパスをAnalyzerに直接設定
-	importcheck.Analyzer.Flags.Set("config", configPath)
+// setFlag はAnalyzerのフラグを設定し、テストの終わりに元の値へ戻す
+func setFlag(t *testing.T, name, value string) {
+	t.Helper()
+
+	f := importcheck.Analyzer.Flags.Lookup(name)
+	if f == nil {
+		t.Fatalf("Unknown flag %q", name)
+	}
+	old := f.Value.String()
+	if err := f.Value.Set(value); err != nil {
+		t.Fatalf("Failed to set flag %q: %v", name, err)
+	}
+	t.Cleanup(func() { f.Value.Set(old) })
+}
+
+// TestAnalyzer は実際のGoファイルに対してAnalyzerを実行するテスト
+func TestAnalyzer(t *testing.T) {
+	testdata := setup(t, nil)
 
-	// テスト実行
 	analysistest.Run(t, testdata, importcheck.Analyzer, "example")
 }
 
@@ -170,3 +193,118 @@ rules:
 		})
 	}
 }
+
+// TestDenySymbols は禁止シンボルの使用を検出できるかテストする
+func TestDenySymbols(t *testing.T) {
+	testdata := setup(t, nil)
+
+	analysistest.Run(t, testdata, importcheck.Analyzer, "symbols")
+}
+
+// TestImportNames はimportの別名・ドットimport・ブランクimportのルールをテストする
+func TestImportNames(t *testing.T) {
+	testdata := setup(t, nil)
+
+	// 別名の修正提案も含めて検証する
+	analysistest.RunWithSuggestedFixes(t, testdata, importcheck.Analyzer, "alias/app")
+}
+
+// TestTestFiles はテストファイルを区別するルールをテストする
+func TestTestFiles(t *testing.T) {
+	testdata := setup(t, nil)
+
+	analysistest.Run(t, testdata, importcheck.Analyzer, "testaware")
+}
+
+// TestBuildConstraints はビルド制約に応じたルールの適用をテストする
+func TestBuildConstraints(t *testing.T) {
+	testdata := setup(t, nil)
+
+	analysistest.Run(t, testdata, importcheck.Analyzer, "buildtags")
+}
+
+// TestGenerated は生成コードの扱いをテストする
+func TestGenerated(t *testing.T) {
+	testdata := setup(t, nil)
+
+	analysistest.Run(t, testdata, importcheck.Analyzer, "generated")
+}
+
+// TestImportBudgets はファイル単位・パッケージ単位のimport数の上限をテストする
+func TestImportBudgets(t *testing.T) {
+	testdata := setup(t, nil)
+
+	analysistest.Run(t, testdata, importcheck.Analyzer, "budget", "budgettests")
+}
+
+// TestSeverity は警告として設定したルールの違反が[warning]付きで報告されることをテストする
+func TestSeverity(t *testing.T) {
+	testdata := setup(t, nil)
+
+	results := analysistest.Run(t, testdata, importcheck.Analyzer, "severity")
+	for _, result := range results {
+		for _, diag := range result.Diagnostics {
+			if diag.Category != config.SeverityWarning {
+				t.Errorf("Expected category %q, got %q", config.SeverityWarning, diag.Category)
+			}
+		}
+	}
+}
+
+// TestDeprecations は非推奨のimportが期限までは警告、期限を過ぎたらエラーとして報告されることをテストする
+func TestDeprecations(t *testing.T) {
+	testdata := setup(t, nil)
+
+	results := analysistest.Run(t, testdata, importcheck.Analyzer, "deprecated/app")
+	for _, result := range results {
+		for _, diag := range result.Diagnostics {
+			want := config.SeverityError
+			if strings.HasPrefix(diag.Message, "[warning]") {
+				want = config.SeverityWarning
+			}
+			if diag.Category != want {
+				t.Errorf("Expected category %q for %q, got %q", want, diag.Message, diag.Category)
+			}
+		}
+	}
+}
+
+// TestExceptions は期限内の例外で違反が許可され、期限切れの例外では違反と期限切れが報告されることをテストする
+func TestExceptions(t *testing.T) {
+	testdata := setup(t, nil)
+
+	analysistest.Run(t, testdata, importcheck.Analyzer, "exceptions")
+}
+
+// TestHierarchical はディレクトリごとの設定ファイルの探索をテストする
+func TestHierarchical(t *testing.T) {
+	testdata := setup(t, map[string]string{"hierarchical": "true"})
+
+	analysistest.Run(t, testdata, importcheck.Analyzer, "hier/...")
+}
+
+// TestHierarchicalVerbose は-hierarchicalでまとめたすべての設定ファイルが-verboseで表示されることをテストする
+func TestHierarchicalVerbose(t *testing.T) {
+	testdata := setup(t, map[string]string{"hierarchical": "true", "verbose": "true"})
+	var buf bytes.Buffer
+	t.Cleanup(importcheck.SetStderr(&buf))
+
+	analysistest.Run(t, testdata, importcheck.Analyzer, "hier/...")
+
+	for _, path := range []string{"src/hier/.llinter.yaml", "src/hier/team/.llinter.yaml"} {
+		want := "llinter: using configuration file " + filepath.Join(testdata, path) + "\n"
+		if strings.Count(buf.String(), want) != 1 {
+			t.Errorf("Expected %q to be reported once, got:\n%s", want, buf.String())
+		}
+	}
+}
+
+// TestWorkspace はgo.workのワークスペースで、各ファイルが属するモジュールのルートからの相対パスと
+// モジュールごとの設定ファイルで照合されることをテストする
+func TestWorkspace(t *testing.T) {
+	dir := filepath.Join(setup(t, map[string]string{"config": config.DefaultConfigName}), "workspace")
+	// ワークスペースモードでは-mod=modを使えないので、環境のGOFLAGSを引き継がない
+	t.Setenv("GOFLAGS", "")
+
+	analysistest.Run(t, dir, importcheck.Analyzer, "example.com/ws/...", "example.com/svc/...")
+}
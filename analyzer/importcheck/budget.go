@@ -0,0 +1,121 @@
+package importcheck
+
+import (
+	"go/ast"
+	"strconv"
+
+	"github.com/blck-snwmn/dependencylintgo/analyzer/config"
+	"github.com/blck-snwmn/dependencylintgo/analyzer/policy"
+	"golang.org/x/tools/go/analysis"
+)
+
+// countsForPackageBudget はファイルのimportをルールのパッケージ単位のimport数の上限に数えるか確認する
+// テストを含むパッケージは本体のファイルも含めてもう一度解析されるので、テストファイルは
+// テストファイルだけに適用するルール（tests: only）の場合にだけ数える
+// ファイル単位の上限はファイルごとに判定するので、テストファイルにも常に適用する
+func countsForPackageBudget(rule *policy.Rule, file config.File) bool {
+	return !file.IsTest || rule.Config.Tests == config.TestsOnly
+}
+
+// packageBudget はパッケージ内でルールが適用されたファイルのimportを集計する
+type packageBudget struct {
+	rule    *policy.Rule
+	file    *ast.File // 最初にルールが適用されたファイル。上限の超過はこのファイルのpackage句に報告する
+	imports map[string]bool
+}
+
+// packageBudgets はルールごとのパッケージ単位の集計だ
+type packageBudgets struct {
+	budgets []*packageBudget
+}
+
+// add はファイルのimportをルールの集計に加える
+func (b *packageBudgets) add(rule *policy.Rule, file *ast.File) {
+	if rule.Config.MaxPackageImports == 0 && rule.Config.MaxPackageThirdPartyImports == 0 {
+		return
+	}
+
+	var budget *packageBudget
+	for _, pb := range b.budgets {
+		if pb.rule == rule {
+			budget = pb
+			break
+		}
+	}
+	if budget == nil {
+		budget = &packageBudget{rule: rule, file: file, imports: make(map[string]bool)}
+		b.budgets = append(b.budgets, budget)
+	}
+
+	for _, importPath := range fileImports(file) {
+		budget.imports[importPath] = true
+	}
+}
+
+// check はパッケージ単位のimport数が上限を超えていないか確認する
+func (b *packageBudgets) check(pass *analysis.Pass) {
+	for _, budget := range b.budgets {
+		imports := make([]string, 0, len(budget.imports))
+		for importPath := range budget.imports {
+			imports = append(imports, importPath)
+		}
+
+		rule := budget.rule.Config
+		if limit := rule.MaxPackageImports; limit > 0 && len(imports) > limit {
+			reportf(pass, budget.rule, budget.file.Package, "package imports %d packages, exceeding max_package_imports %d based on configuration", len(imports), limit)
+		}
+		if limit := rule.MaxPackageThirdPartyImports; limit > 0 {
+			if n := countThirdParty(pass, imports); n > limit {
+				reportf(pass, budget.rule, budget.file.Package, "package imports %d third-party packages, exceeding max_package_third_party_imports %d based on configuration", n, limit)
+			}
+		}
+	}
+}
+
+// checkFileBudget はファイル単位のimport数が上限を超えていないか確認する
+func checkFileBudget(pass *analysis.Pass, rule *policy.Rule, file *ast.File) {
+	if rule.Config.MaxImports == 0 && rule.Config.MaxThirdPartyImports == 0 {
+		return
+	}
+
+	imports := fileImports(file)
+	if limit := rule.Config.MaxImports; limit > 0 && len(imports) > limit {
+		reportf(pass, rule, file.Package, "file imports %d packages, exceeding max_imports %d based on configuration", len(imports), limit)
+	}
+	if limit := rule.Config.MaxThirdPartyImports; limit > 0 {
+		if n := countThirdParty(pass, imports); n > limit {
+			reportf(pass, rule, file.Package, "file imports %d third-party packages, exceeding max_third_party_imports %d based on configuration", n, limit)
+		}
+	}
+}
+
+// fileImports はファイルがimportしている異なるパッケージを返す
+func fileImports(file *ast.File) []string {
+	seen := make(map[string]bool, len(file.Imports))
+	var imports []string
+	for _, spec := range file.Imports {
+		importPath, err := strconv.Unquote(spec.Path.Value)
+		if err != nil || seen[importPath] {
+			continue
+		}
+		seen[importPath] = true
+		imports = append(imports, importPath)
+	}
+	return imports
+}
+
+// countThirdParty は外部モジュールのパッケージの数を数える
+func countThirdParty(pass *analysis.Pass, imports []string) int {
+	modulePath := ""
+	if pass.Module != nil {
+		modulePath = pass.Module.Path
+	}
+
+	n := 0
+	for _, importPath := range imports {
+		if policy.ClassifyImport(importPath, modulePath) == policy.ImportThirdParty {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,15 @@
+package importcheck
+
+import "io"
+
+// SetStderr は使用した設定ファイルの表示先を差し替え、表示済みの記録を消す
+// テストから-verboseの表示を確かめるために公開する
+func SetStderr(w io.Writer) func() {
+	old := stderr
+	stderr = w
+	reportedConfigs.Clear()
+	return func() {
+		stderr = old
+		reportedConfigs.Clear()
+	}
+}
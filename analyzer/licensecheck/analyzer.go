@@ -0,0 +1,169 @@
+package licensecheck
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/blck-snwmn/dependencylintgo/analyzer/config"
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+	"golang.org/x/tools/go/analysis"
+)
+
+var (
+	configFile string
+
+	// モジュールのライセンスはパッケージをまたいで同じなので、ディレクトリごとに一度だけ調べる
+	licenseCache sync.Map // map[string]*moduleLicense
+)
+
+// Analyzer はimportした外部モジュールのライセンスを設定のlicensesに照らしてチェックするanalyzerだ
+// ライセンスはvendorかモジュールキャッシュにあるライセンスファイルから判別し、ネットワークには問い合わせない
+var Analyzer = &analysis.Analyzer{
+	Name: "licensecheck",
+	Doc:  "checks licenses of imported third-party modules against configuration",
+	Run:  run,
+}
+
+func init() {
+	Analyzer.Flags.StringVar(&configFile, "config", ".llinter.yaml", "configuration file path")
+}
+
+// moduleLicense はモジュールのライセンスを判別した結果だ
+type moduleLicense struct {
+	licenses []string // 判別できたライセンスのSPDX識別子
+	unknown  string   // 判別できなかった理由。判別できた場合は空
+}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	// GOPATHモードではgo.modがない
+	if pass.Module == nil || len(pass.Files) == 0 {
+		return nil, nil
+	}
+	root, modulePath, err := config.FindModule(filepath.Dir(pass.Fset.Position(pass.Files[0].Package).Filename))
+	if err != nil || modulePath != pass.Module.Path {
+		return nil, nil
+	}
+
+	// 設定ファイルの読み込み（go.workのワークスペースではモジュールごとの設定ファイルを優先する）
+	var cfg *config.Config
+	path, err := config.ResolveConfigPathFor(configFile, root)
+	if err == nil {
+		cfg, err = config.LoadConfig(path)
+	}
+	if err != nil {
+		// 設定ファイルが見つからない場合はスキップする
+		if config.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if cfg.Licenses == nil {
+		return nil, nil
+	}
+
+	gomod := filepath.Join(root, "go.mod")
+	data, err := os.ReadFile(gomod)
+	if err != nil {
+		return nil, err
+	}
+	f, err := modfile.Parse(gomod, data, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// go.workのワークスペースでは、vendorはgo.workのあるディレクトリに作られる
+	vendorRoot := root
+	if ws, err := config.FindWorkspace(root); err == nil && ws.Contains(root) {
+		vendorRoot = ws.Dir
+	}
+
+	for _, file := range pass.Files {
+		for _, spec := range file.Imports {
+			importPath, err := strconv.Unquote(spec.Path.Value)
+			if err != nil {
+				continue
+			}
+			// requireに一致しないimportは標準ライブラリか同じモジュールのパッケージ
+			required, replacement, ok := config.ResolveModule(f, importPath)
+			if !ok {
+				continue
+			}
+
+			mod := required
+			if replacement.Version != "" {
+				mod = replacement
+			}
+			result := lookupLicense(root, vendorRoot, required, replacement)
+
+			if result.unknown != "" {
+				if !cfg.Licenses.AllowUnknown {
+					pass.Reportf(spec.Pos(), "import %q uses module %s@%s with unknown license (%s), which is not allowed based on configuration", importPath, mod.Path, mod.Version, result.unknown)
+				}
+				continue
+			}
+
+			var denied []string
+			for _, license := range result.licenses {
+				if !licenseAllowed(cfg.Licenses, license) {
+					denied = append(denied, license)
+				}
+			}
+			if len(denied) > 0 {
+				pass.Reportf(spec.Pos(), "import %q uses module %s@%s licensed under %s, which is not allowed based on configuration", importPath, mod.Path, mod.Version, strings.Join(denied, ", "))
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+// lookupLicense はモジュールのライセンスを判別する
+func lookupLicense(root, vendorRoot string, required, replacement module.Version) *moduleLicense {
+	dir, ok := findModuleDir(root, vendorRoot, required, replacement)
+	if !ok {
+		return &moduleLicense{unknown: "module source not found in vendor or module cache"}
+	}
+	if cached, ok := licenseCache.Load(dir); ok {
+		return cached.(*moduleLicense)
+	}
+
+	result := &moduleLicense{}
+	licenses, files, err := detectLicenses(dir)
+	switch {
+	case err != nil:
+		result.unknown = err.Error()
+	case len(files) == 0:
+		result.unknown = "no license file"
+	case len(licenses) == 0:
+		result.unknown = fmt.Sprintf("unrecognized license file %s", strings.Join(files, ", "))
+	default:
+		result.licenses = licenses
+	}
+
+	cached, _ := licenseCache.LoadOrStore(dir, result)
+	return cached.(*moduleLicense)
+}
+
+// licenseAllowed はライセンスが設定で許可されているか確認する
+// denyに一致すれば禁止し、allowが空でなければallowに一致するものだけ許可する
+func licenseAllowed(l *config.Licenses, license string) bool {
+	if matchLicense(l.Deny, license) {
+		return false
+	}
+	return len(l.Allow) == 0 || matchLicense(l.Allow, license)
+}
+
+// matchLicense はライセンスがパターンのいずれかに一致するか確認する（大文字小文字は区別しない）
+func matchLicense(patterns []string, license string) bool {
+	for _, pattern := range patterns {
+		if ok, err := filepath.Match(strings.ToLower(pattern), strings.ToLower(license)); err == nil && ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,23 @@
+package licensecheck_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/blck-snwmn/dependencylintgo/analyzer/licensecheck"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+// TestAnalyzer はimportした外部モジュールのライセンスのチェックをテストする
+func TestAnalyzer(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get working directory: %v", err)
+	}
+
+	dir := filepath.Join(wd, "../..", "testdata", "licensecheck")
+	licensecheck.Analyzer.Flags.Set("config", filepath.Join(dir, ".llinter.yaml"))
+
+	analysistest.Run(t, dir, licensecheck.Analyzer, "example.com/licensecheck")
+}
@@ -0,0 +1,7 @@
+package licensecheck
+
+// Classify とFindModuleDirはテストからライセンスの判別とモジュールの探索を確かめるために公開する
+var (
+	Classify      = classify
+	FindModuleDir = findModuleDir
+)
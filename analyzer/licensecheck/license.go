@@ -0,0 +1,189 @@
+package licensecheck
+
+import (
+	"go/build"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"golang.org/x/mod/module"
+)
+
+// licenseFilePrefixes はライセンスファイルとみなすファイル名の接頭辞だ（大文字小文字は区別しない）
+var licenseFilePrefixes = []string{"license", "licence", "copying", "unlicense"}
+
+// spdxIdentifier はSPDX-License-Identifierの行に一致する
+var spdxIdentifier = regexp.MustCompile(`(?i)spdx-license-identifier:\s*([a-z0-9.+-]+)`)
+
+// gplFamilies はGNUのライセンスの名前とSPDX識別子の接頭辞だ
+// GPLの本文はLGPLに、LGPLの本文はGPLに言及するので、本文で最初に現れたものを採る
+var gplFamilies = []struct {
+	name   string
+	prefix string
+}{
+	{"gnu affero general public license", "AGPL"},
+	{"gnu lesser general public license", "LGPL"},
+	{"gnu library general public license", "LGPL"},
+	{"gnu general public license", "GPL"},
+}
+
+// findModuleDir はモジュールのソースがあるディレクトリを返す
+// vendorRoot（モジュールルート、go.workのワークスペースではgo.workのあるディレクトリ）に
+// vendor/modules.txtがあればvendorを、なければモジュールキャッシュを探す
+// replaceでローカルのディレクトリに置き換えられている場合はモジュールルートからのそのディレクトリを返す
+func findModuleDir(root, vendorRoot string, required, replacement module.Version) (string, bool) {
+	if replacement.Path != "" && replacement.Version == "" {
+		dir := replacement.Path
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(root, dir)
+		}
+		return dir, isDir(dir)
+	}
+
+	// vendorには置き換え前のモジュールパスで置かれる
+	if _, err := os.Stat(filepath.Join(vendorRoot, "vendor", "modules.txt")); err == nil {
+		dir := filepath.Join(vendorRoot, "vendor", filepath.FromSlash(required.Path))
+		return dir, isDir(dir)
+	}
+
+	mod := required
+	if replacement.Path != "" {
+		mod = replacement
+	}
+	escapedPath, err := module.EscapePath(mod.Path)
+	if err != nil {
+		return "", false
+	}
+	escapedVersion, err := module.EscapeVersion(mod.Version)
+	if err != nil {
+		return "", false
+	}
+	dir := filepath.Join(modCache(), filepath.FromSlash(escapedPath)+"@"+escapedVersion)
+	return dir, isDir(dir)
+}
+
+// modCache はモジュールキャッシュのディレクトリを返す
+// goコマンドと同じく、GOMODCACHEがなければGOPATHの最初の要素のpkg/modを使う
+func modCache() string {
+	if dir := os.Getenv("GOMODCACHE"); dir != "" {
+		return dir
+	}
+	gopath := filepath.SplitList(build.Default.GOPATH)
+	if len(gopath) == 0 {
+		return ""
+	}
+	return filepath.Join(gopath[0], "pkg", "mod")
+}
+
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
+
+// detectLicenses はディレクトリ直下のライセンスファイルを読み、判別できたライセンスのSPDX識別子を返す
+// ライセンスファイルがなければfilesは空になる
+func detectLicenses(dir string) (licenses, files []string, err error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	seen := make(map[string]bool)
+	for _, entry := range entries {
+		if entry.IsDir() || !isLicenseFile(entry.Name()) {
+			continue
+		}
+		files = append(files, entry.Name())
+
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, nil, err
+		}
+		if id := classify(string(data)); id != "" && !seen[id] {
+			seen[id] = true
+			licenses = append(licenses, id)
+		}
+	}
+	sort.Strings(licenses)
+	return licenses, files, nil
+}
+
+func isLicenseFile(name string) bool {
+	lower := strings.ToLower(name)
+	for _, prefix := range licenseFilePrefixes {
+		if strings.HasPrefix(lower, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// classify はライセンスの本文からSPDX識別子を推定する。判別できなければ空文字列を返す
+// ネットワークには問い合わせず、よく使われるライセンスの特徴的な文言だけで判別する
+func classify(text string) string {
+	if m := spdxIdentifier.FindStringSubmatch(text); m != nil {
+		return m[1]
+	}
+
+	// 改行や字下げの違いを吸収する
+	t := strings.Join(strings.Fields(strings.ToLower(text)), " ")
+
+	if id := classifyGNU(t); id != "" {
+		return id
+	}
+
+	switch {
+	case strings.Contains(t, "apache license") && strings.Contains(t, "version 2.0"):
+		return "Apache-2.0"
+	case strings.Contains(t, "mozilla public license version 2.0"), strings.Contains(t, "mozilla public license, version 2.0"):
+		return "MPL-2.0"
+	case strings.Contains(t, "this is free and unencumbered software released into the public domain"):
+		return "Unlicense"
+	case strings.Contains(t, "cc0 1.0 universal"):
+		return "CC0-1.0"
+	case strings.Contains(t, "permission is hereby granted, free of charge"):
+		return "MIT"
+	case strings.Contains(t, "redistribution and use in source and binary forms"):
+		if strings.Contains(t, "neither the name") || strings.Contains(t, "may be used to endorse or promote products") {
+			return "BSD-3-Clause"
+		}
+		return "BSD-2-Clause"
+	case strings.Contains(t, "permission to use, copy, modify, and/or distribute this software for any purpose"),
+		strings.Contains(t, "permission to use, copy, modify, and distribute this software for any purpose"):
+		if strings.Contains(t, "provided that the above copyright notice") {
+			return "ISC"
+		}
+		return "0BSD"
+	}
+	return ""
+}
+
+// classifyGNU はGNUのライセンスを、本文で最初に現れた名前とその直後のバージョンから判別する
+func classifyGNU(t string) string {
+	first, prefix := -1, ""
+	for _, family := range gplFamilies {
+		if i := strings.Index(t, family.name); i >= 0 && (first < 0 || i < first) {
+			first, prefix = i, family.prefix
+		}
+	}
+	if first < 0 {
+		return ""
+	}
+
+	rest := t[first:]
+	if len(rest) > 200 {
+		rest = rest[:200]
+	}
+	for _, v := range []struct{ text, id string }{
+		{"version 2.1", "2.1"},
+		{"version 3", "3.0"},
+		{"version 2", "2.0"},
+	} {
+		if strings.Contains(rest, v.text) {
+			return prefix + "-" + v.id
+		}
+	}
+	return ""
+}
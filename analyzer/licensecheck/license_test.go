@@ -0,0 +1,156 @@
+package licensecheck_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/blck-snwmn/dependencylintgo/analyzer/licensecheck"
+	"golang.org/x/mod/module"
+)
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{
+			name: "SPDX識別子",
+			text: "// SPDX-License-Identifier: MPL-2.0\n",
+			want: "MPL-2.0",
+		},
+		{
+			name: "MIT",
+			text: "Permission is hereby granted, free of charge, to any person\nobtaining a copy of this software",
+			want: "MIT",
+		},
+		{
+			name: "Apache-2.0",
+			text: "                              Apache License\n                        Version 2.0, January 2004",
+			want: "Apache-2.0",
+		},
+		{
+			name: "BSD-3-Clause",
+			text: "Redistribution and use in source and binary forms, with or without\nmodification, are permitted.\nNeither the name of the copyright holder nor the names of its contributors",
+			want: "BSD-3-Clause",
+		},
+		{
+			name: "BSD-2-Clause",
+			text: "Redistribution and use in source and binary forms, with or without\nmodification, are permitted.",
+			want: "BSD-2-Clause",
+		},
+		{
+			name: "ISC",
+			text: "Permission to use, copy, modify, and/or distribute this software for any\npurpose with or without fee is hereby granted, provided that the above\ncopyright notice and this permission notice appear in all copies.",
+			want: "ISC",
+		},
+		{
+			name: "GPL-2.0",
+			text: "GNU GENERAL PUBLIC LICENSE\nVersion 2, June 1991",
+			want: "GPL-2.0",
+		},
+		{
+			name: "GPLの本文がLGPLに言及してもGPLと判別する",
+			text: "GNU GENERAL PUBLIC LICENSE\nVersion 3, 29 June 2007\n...consider it more useful to permit linking proprietary applications with the library.\nIf this is what you want to do, use the GNU Lesser General Public License instead of this License.",
+			want: "GPL-3.0",
+		},
+		{
+			name: "LGPL-2.1",
+			text: "GNU LESSER GENERAL PUBLIC LICENSE\nVersion 2.1, February 1999\n\nThis license, the Lesser General Public License, applies to some\nspecially designated software packages--typically libraries--of the\nFree Software Foundation. See the GNU General Public License.",
+			want: "LGPL-2.1",
+		},
+		{
+			name: "AGPL-3.0",
+			text: "GNU AFFERO GENERAL PUBLIC LICENSE\nVersion 3, 19 November 2007",
+			want: "AGPL-3.0",
+		},
+		{
+			name: "判別できない",
+			text: "All rights reserved.",
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := licensecheck.Classify(tt.text); got != tt.want {
+				t.Errorf("Classify() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindModuleDir(t *testing.T) {
+	mod := module.Version{Path: "github.com/Example/lib", Version: "v1.2.0"}
+
+	t.Run("モジュールキャッシュ", func(t *testing.T) {
+		cache := t.TempDir()
+		t.Setenv("GOMODCACHE", cache)
+		want := filepath.Join(cache, "github.com", "!example", "lib@v1.2.0")
+		mkdirAll(t, want)
+
+		root := t.TempDir()
+		got, ok := licensecheck.FindModuleDir(root, root, mod, module.Version{})
+		if !ok || got != want {
+			t.Errorf("FindModuleDir() = %q, %v, want %q, true", got, ok, want)
+		}
+	})
+
+	t.Run("vendor", func(t *testing.T) {
+		t.Setenv("GOMODCACHE", t.TempDir())
+		root := t.TempDir()
+		want := filepath.Join(root, "vendor", "github.com", "Example", "lib")
+		mkdirAll(t, want)
+		if err := os.WriteFile(filepath.Join(root, "vendor", "modules.txt"), nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		got, ok := licensecheck.FindModuleDir(root, root, mod, module.Version{})
+		if !ok || got != want {
+			t.Errorf("FindModuleDir() = %q, %v, want %q, true", got, ok, want)
+		}
+	})
+
+	t.Run("ワークスペースのvendor", func(t *testing.T) {
+		t.Setenv("GOMODCACHE", t.TempDir())
+		workspace := t.TempDir()
+		want := filepath.Join(workspace, "vendor", "github.com", "Example", "lib")
+		mkdirAll(t, want)
+		if err := os.WriteFile(filepath.Join(workspace, "vendor", "modules.txt"), nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		got, ok := licensecheck.FindModuleDir(filepath.Join(workspace, "svc"), workspace, mod, module.Version{})
+		if !ok || got != want {
+			t.Errorf("FindModuleDir() = %q, %v, want %q, true", got, ok, want)
+		}
+	})
+
+	t.Run("ローカルのディレクトリへのreplace", func(t *testing.T) {
+		root := t.TempDir()
+		want := filepath.Join(root, "third_party", "lib")
+		mkdirAll(t, want)
+
+		got, ok := licensecheck.FindModuleDir(root, root, mod, module.Version{Path: "./third_party/lib"})
+		if !ok || got != want {
+			t.Errorf("FindModuleDir() = %q, %v, want %q, true", got, ok, want)
+		}
+	})
+
+	t.Run("見つからない", func(t *testing.T) {
+		t.Setenv("GOMODCACHE", t.TempDir())
+
+		root := t.TempDir()
+		if _, ok := licensecheck.FindModuleDir(root, root, mod, module.Version{}); ok {
+			t.Error("FindModuleDir() expected not found")
+		}
+	})
+}
+
+func mkdirAll(t *testing.T, dir string) {
+	t.Helper()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,239 @@
+package modcheck
+
+import (
+	"go/token"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+
+	"github.com/blck-snwmn/dependencylintgo/analyzer/config"
+	"golang.org/x/mod/modfile"
+	"golang.org/x/tools/go/analysis"
+)
+
+var configFile string
+
+// Analyzer はgo.modのrequire/replace/toolを設定のmodulesに照らしてチェックするanalyzerだ
+//
+// go.modはモジュールのすべてのパッケージで同じなので、同じ診断がパッケージごとに報告されるが、
+// 位置とメッセージが同じ診断はドライバがまとめて表示する
+var Analyzer = &analysis.Analyzer{
+	Name: "modcheck",
+	Doc:  "checks go.mod requirements against configured module rules",
+	Run:  run,
+}
+
+func init() {
+	Analyzer.Flags.StringVar(&configFile, "config", ".llinter.yaml", "configuration file path")
+}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	// GOPATHモードではgo.modがない
+	if pass.Module == nil || len(pass.Files) == 0 {
+		return nil, nil
+	}
+	root, modulePath, err := config.FindModule(filepath.Dir(pass.Fset.Position(pass.Files[0].Package).Filename))
+	if err != nil || modulePath != pass.Module.Path {
+		return nil, nil
+	}
+	gomod := filepath.Join(root, "go.mod")
+
+	// 設定ファイルの読み込み（go.workのワークスペースではモジュールごとの設定ファイルを優先する）
+	var cfg *config.Config
+	path, err := config.ResolveConfigPathFor(configFile, root)
+	if err == nil {
+		cfg, err = config.LoadConfig(path)
+	}
+	if err != nil {
+		// 設定ファイルが見つからない場合はスキップする
+		if config.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if cfg.Modules == nil {
+		return nil, nil
+	}
+
+	data, err := os.ReadFile(gomod)
+	if err != nil {
+		return nil, err
+	}
+	f, err := modfile.Parse(gomod, data, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// 診断の位置を示せるように、go.modをファイルセットに加える
+	tf := pass.Fset.AddFile(gomod, -1, len(data))
+	tf.SetLinesForContent(data)
+	pos := func(line *modfile.Line) token.Pos {
+		if line == nil {
+			return tf.Pos(0)
+		}
+		return tf.Pos(line.Start.Byte)
+	}
+
+	rules, err := compileRules(cfg.Modules)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, r := range f.Require {
+		if rules.denied(r.Mod.Path) {
+			pass.Reportf(pos(r.Syntax), "module %q is not allowed based on configuration", r.Mod.Path)
+		}
+		if rules.banned(r.Mod.Path, r.Mod.Version) {
+			pass.Reportf(pos(r.Syntax), "module %s@%s is banned based on configuration", r.Mod.Path, r.Mod.Version)
+		}
+		if c, ok := rules.violated(r.Mod.Path, r.Mod.Version); ok {
+			pass.Reportf(pos(r.Syntax), "module %s@%s does not satisfy version constraint %q based on configuration", r.Mod.Path, r.Mod.Version, c)
+		}
+	}
+
+	for _, r := range f.Replace {
+		// バージョンのないreplace先はローカルのディレクトリ
+		if r.New.Version == "" {
+			if cfg.Modules.DenyLocalReplace {
+				pass.Reportf(pos(r.Syntax), "replace of %q with local path %q is not allowed based on configuration", r.Old.Path, r.New.Path)
+			}
+			continue
+		}
+		if rules.denied(r.New.Path) {
+			pass.Reportf(pos(r.Syntax), "replacement module %q is not allowed based on configuration", r.New.Path)
+		}
+		if rules.banned(r.New.Path, r.New.Version) {
+			pass.Reportf(pos(r.Syntax), "module %s@%s is banned based on configuration", r.New.Path, r.New.Version)
+		}
+		if c, ok := rules.violated(r.New.Path, r.New.Version); ok {
+			pass.Reportf(pos(r.Syntax), "module %s@%s does not satisfy version constraint %q based on configuration", r.New.Path, r.New.Version, c)
+		}
+	}
+
+	for _, t := range f.Tool {
+		if rules.denied(t.Path) {
+			pass.Reportf(pos(t.Syntax), "tool %q is not allowed based on configuration", t.Path)
+		}
+	}
+
+	checkImports(pass, f, rules)
+
+	return nil, nil
+}
+
+// checkImports はimportしたパッケージのモジュールのバージョンが制約を満たすか確認する
+// バージョンはgo.modのrequireから求め、バージョン付きのreplaceがあれば置き換え先のものを使う
+func checkImports(pass *analysis.Pass, f *modfile.File, rules *moduleRules) {
+	if len(rules.versions) == 0 {
+		return
+	}
+
+	for _, file := range pass.Files {
+		for _, spec := range file.Imports {
+			importPath, err := strconv.Unquote(spec.Path.Value)
+			if err != nil {
+				continue
+			}
+			mod, replacement, ok := config.ResolveModule(f, importPath)
+			if !ok {
+				continue
+			}
+			// ローカルのディレクトリへの置き換えはrequireのバージョンで評価する
+			if replacement.Version != "" {
+				mod = replacement
+			}
+			if c, ok := rules.violated(mod.Path, mod.Version); ok {
+				pass.Reportf(spec.Pos(), "import %q uses module %s@%s, which does not satisfy version constraint %q based on configuration", importPath, mod.Path, mod.Version, c)
+			}
+		}
+	}
+}
+
+// moduleRules はコンパイル済みのmodulesの設定だ
+type moduleRules struct {
+	deny     config.Patterns
+	allow    config.Patterns
+	bans     []bannedVersions
+	versions []versionRule
+}
+
+// versionRule はパターンに一致するモジュールのバージョン制約だ
+type versionRule struct {
+	patterns   config.Patterns
+	constraint config.VersionConstraint
+}
+
+// bannedVersions はパターンに一致するモジュールで禁止するバージョンだ
+type bannedVersions struct {
+	patterns config.Patterns
+	versions []string
+}
+
+func compileRules(m *config.Modules) (*moduleRules, error) {
+	r := &moduleRules{
+		deny:  config.CompileImportPatterns(m.Deny),
+		allow: config.CompileImportPatterns(m.Allow),
+	}
+
+	patterns := make([]string, 0, len(m.BannedVersions))
+	for pattern := range m.BannedVersions {
+		patterns = append(patterns, pattern)
+	}
+	sort.Strings(patterns)
+	for _, pattern := range patterns {
+		r.bans = append(r.bans, bannedVersions{
+			patterns: config.CompileImportPatterns([]string{pattern}),
+			versions: m.BannedVersions[pattern],
+		})
+	}
+
+	patterns = patterns[:0]
+	for pattern := range m.Versions {
+		patterns = append(patterns, pattern)
+	}
+	sort.Strings(patterns)
+	for _, pattern := range patterns {
+		c, err := config.ParseVersionConstraint(m.Versions[pattern])
+		if err != nil {
+			return nil, err
+		}
+		r.versions = append(r.versions, versionRule{
+			patterns:   config.CompileImportPatterns([]string{pattern}),
+			constraint: c,
+		})
+	}
+	return r, nil
+}
+
+// denied はモジュールパスがdenyに一致し、allowに一致しないか確認する
+// toolのパスはパッケージパスなので、モジュールパスと同じパターンで照合する
+func (r *moduleRules) denied(path string) bool {
+	if _, ok := r.deny.Match(path); !ok {
+		return false
+	}
+	_, ok := r.allow.Match(path)
+	return !ok
+}
+
+// banned はモジュールのバージョンが禁止されているか確認する
+func (r *moduleRules) banned(path, version string) bool {
+	for _, b := range r.bans {
+		if _, ok := b.patterns.Match(path); ok && slices.Contains(b.versions, version) {
+			return true
+		}
+	}
+	return false
+}
+
+// violated はモジュールのバージョンが満たさない制約を返す
+// 複数のパターンに一致する場合はパターンの辞書順で最初に満たさなかったものを返す
+func (r *moduleRules) violated(path, version string) (config.VersionConstraint, bool) {
+	for _, v := range r.versions {
+		if _, ok := v.patterns.Match(path); ok && !v.constraint.Allows(version) {
+			return v.constraint, true
+		}
+	}
+	return config.VersionConstraint{}, false
+}
@@ -0,0 +1,67 @@
+package modcheck_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/blck-snwmn/dependencylintgo/analyzer/modcheck"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+// recorder はgo.modの診断を// wantで書けないので、analysistestのエラーを集める
+type recorder struct {
+	errors []string
+}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+// TestAnalyzer はgo.modのrequire/replace/toolとimportのバージョン制約のチェックをテストする
+func TestAnalyzer(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get working directory: %v", err)
+	}
+
+	dir := filepath.Join(wd, "../..", "testdata", "modcheck")
+	modcheck.Analyzer.Flags.Set("config", filepath.Join(dir, ".llinter.yaml"))
+
+	rec := &recorder{}
+	results := analysistest.Run(rec, dir, modcheck.Analyzer, "example.com/modcheck")
+
+	var got []string
+	for _, result := range results {
+		for _, diag := range result.Diagnostics {
+			posn := result.Pass.Fset.Position(diag.Pos)
+			got = append(got, fmt.Sprintf("%s:%d: %s", filepath.Base(posn.Filename), posn.Line, diag.Message))
+		}
+	}
+	sort.Strings(got)
+
+	want := []string{
+		`go.mod:11: replace of "example.org/banned" with local path "./third_party/banned" is not allowed based on configuration`,
+		`go.mod:14: replace of "example.org/ok" with local path "./third_party/ok" is not allowed based on configuration`,
+		`go.mod:15: replace of "example.org/old" with local path "./third_party/old" is not allowed based on configuration`,
+		`go.mod:19: tool "example.org/banned/cmd/x" is not allowed based on configuration`,
+		`go.mod:6: module "example.org/banned" is not allowed based on configuration`,
+		`go.mod:8: module "example.org/old" is not allowed based on configuration`,
+		`go.mod:8: module example.org/old@v1.2.3 does not satisfy version constraint ">= v1.5.0" based on configuration`,
+		`go.mod:8: module example.org/old@v1.2.3 is banned based on configuration`,
+		`modcheck.go:3: import "example.org/old" uses module example.org/old@v1.2.3, which does not satisfy version constraint ">= v1.5.0" based on configuration`,
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Unexpected diagnostics:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	// go.modには// wantを書けないので、予期しない診断としてのエラーだけが出る
+	for _, e := range rec.errors {
+		if !strings.Contains(e, "go.mod") {
+			t.Error(e)
+		}
+	}
+}
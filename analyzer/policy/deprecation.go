@@ -0,0 +1,75 @@
+package policy
+
+import (
+	"sort"
+
+	"github.com/blck-snwmn/dependencylintgo/analyzer/config"
+)
+
+// deprecation はコンパイル済みの非推奨のimportだ
+type deprecation struct {
+	index    int
+	config   *config.Deprecation
+	patterns config.Patterns
+}
+
+// Deprecation は非推奨のimportの判定結果だ
+type Deprecation struct {
+	Index    int                 // 設定のdeprecate中の位置
+	Config   *config.Deprecation // 元になった設定
+	Expired  bool                // 期限を過ぎたか
+	Severity string              // 期限までは警告（warning）、過ぎたらエラー（error）
+}
+
+func compileDeprecations(cfg *config.Config) []deprecation {
+	deprecations := make([]deprecation, len(cfg.Deprecate))
+	for i := range cfg.Deprecate {
+		deprecations[i] = deprecation{
+			index:    i,
+			config:   &cfg.Deprecate[i],
+			patterns: config.CompileImportPatterns([]string{cfg.Deprecate[i].Import}),
+		}
+	}
+	return deprecations
+}
+
+// Deprecated はファイルのimportPathが非推奨か判定する
+// todayが期限（until）を過ぎていればエラーになる。複数に一致する場合は設定で先に書かれたものを使う
+// ルールと同じく、設定全体の生成コードの扱い（generated）で対象外になるファイルは判定しない
+func (p *Policy) Deprecated(file config.File, importPath string, today config.Date) (Deprecation, bool) {
+	if config.GeneratedReason(p.generated, file) != "" {
+		return Deprecation{}, false
+	}
+	for _, d := range p.deprecations {
+		if _, ok := d.patterns.Match(importPath); ok {
+			return d.evaluate(today), true
+		}
+	}
+	return Deprecation{}, false
+}
+
+// Schedule は非推奨のimportを期限の早い順に返す
+func (p *Policy) Schedule(today config.Date) []Deprecation {
+	schedule := make([]Deprecation, 0, len(p.deprecations))
+	for _, d := range p.deprecations {
+		schedule = append(schedule, d.evaluate(today))
+	}
+	sort.SliceStable(schedule, func(i, j int) bool {
+		return schedule[i].Config.Until.Before(schedule[j].Config.Until)
+	})
+	return schedule
+}
+
+// evaluate はtodayの時点での判定結果を返す
+func (d deprecation) evaluate(today config.Date) Deprecation {
+	result := Deprecation{
+		Index:    d.index,
+		Config:   d.config,
+		Expired:  today.After(d.config.Until),
+		Severity: config.SeverityWarning,
+	}
+	if result.Expired {
+		result.Severity = config.SeverityError
+	}
+	return result
+}
@@ -0,0 +1,102 @@
+package policy
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/blck-snwmn/dependencylintgo/analyzer/config"
+)
+
+// exception はコンパイル済みの例外だ
+type exception struct {
+	index    int
+	config   *config.Exception
+	paths    config.Patterns
+	packages config.Patterns
+	imports  config.Patterns
+}
+
+// Exception は例外の判定結果だ
+type Exception struct {
+	Index   int               // 設定のexceptions中の位置
+	Config  *config.Exception // 元になった設定
+	Expired bool              // 期限を過ぎたか
+}
+
+func compileExceptions(cfg *config.Config) []exception {
+	exceptions := make([]exception, len(cfg.Exceptions))
+	for i := range cfg.Exceptions {
+		e := &cfg.Exceptions[i]
+		exceptions[i] = exception{
+			index:    i,
+			config:   e,
+			paths:    config.CompileFilePatterns(e.Path),
+			packages: config.CompileImportPatterns(e.Packages),
+			imports:  config.CompileImportPatterns([]string{e.Import}),
+		}
+	}
+	return exceptions
+}
+
+// ExceptionFor はパッケージpkgのファイルでのimportPathのimportに適用する例外を返す
+// pathとpackagesの両方を指定した例外はどちらにも一致する場合だけ適用する
+// 期限内の例外を優先し、なければ期限切れの例外のうち設定で先に書かれたものを返す
+func (p *Policy) ExceptionFor(file config.File, pkg, importPath string, today config.Date) (Exception, bool) {
+	pkg = strings.TrimSuffix(pkg, "_test")
+
+	var (
+		found Exception
+		ok    bool
+	)
+	for _, e := range p.exceptions {
+		if !e.matches(file, pkg, importPath) {
+			continue
+		}
+		result := e.evaluate(today)
+		if !result.Expired {
+			return result, true
+		}
+		if !ok {
+			found, ok = result, true
+		}
+	}
+	return found, ok
+}
+
+// Exceptions は例外を期限の早い順に返す
+func (p *Policy) Exceptions(today config.Date) []Exception {
+	exceptions := make([]Exception, 0, len(p.exceptions))
+	for _, e := range p.exceptions {
+		exceptions = append(exceptions, e.evaluate(today))
+	}
+	sort.SliceStable(exceptions, func(i, j int) bool {
+		return exceptions[i].Config.Expires.Before(exceptions[j].Config.Expires)
+	})
+	return exceptions
+}
+
+func (e exception) matches(file config.File, pkg, importPath string) bool {
+	if _, ok := e.imports.Match(importPath); !ok {
+		return false
+	}
+	if e.paths.Len() > 0 {
+		if _, ok := e.paths.Match(file.Path); !ok {
+			return false
+		}
+	}
+	if e.packages.Len() > 0 {
+		if _, ok := e.packages.Match(pkg); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// evaluate はtodayの時点での判定結果を返す
+func (e exception) evaluate(today config.Date) Exception {
+	return Exception{
+		Index:   e.index,
+		Config:  e.config,
+		Expired: today.After(e.config.Expires),
+	}
+}
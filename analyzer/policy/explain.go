@@ -0,0 +1,43 @@
+package policy
+
+import (
+	"github.com/blck-snwmn/dependencylintgo/analyzer/config"
+)
+
+// Explanation はimportの可否を判定した過程だ
+type Explanation struct {
+	File       config.File        // 判定に使ったファイルの情報
+	ImportPath string             // 判定したimportパス
+	Rules      []config.RuleMatch // 適用するルールを決めるまでに判定したルール
+	Deny       string             // 適用されたルールで一致したdenyパターン。なければ空
+	Allow      string             // 適用されたルールで一致したallowパターン。なければ空
+	Inherited  []int              // 上位のディレクトリの設定ファイルから引き継いだルールの位置
+	Decision   Decision           // 最終的な判定
+}
+
+// Explain はCheckと同じ判定を行い、その過程を返す
+func (p *Policy) Explain(file config.File, pkg, importPath string) Explanation {
+	return p.ExplainAt(file, pkg, importPath, config.Today())
+}
+
+// ExplainAt はtodayの時点でCheckAtと同じ判定を行い、その過程を返す
+func (p *Policy) ExplainAt(file config.File, pkg, importPath string, today config.Date) Explanation {
+	file = packageFile(file, pkg)
+
+	e := Explanation{
+		File:       file,
+		ImportPath: importPath,
+		Rules:      p.rules.Trace(file),
+	}
+
+	rules := p.rulesFor(file)
+	if len(rules) > 0 {
+		e.Deny, _ = rules[0].deny.Match(importPath)
+		e.Allow, _ = rules[0].allow.Match(importPath)
+		for _, rule := range rules[1:] {
+			e.Inherited = append(e.Inherited, rule.Index)
+		}
+	}
+	e.Decision = p.decide(rules, file, pkg, importPath, today)
+	return e
+}
@@ -0,0 +1,25 @@
+package policy
+
+import "strings"
+
+// ImportKind はimportするパッケージの種類だ
+type ImportKind int
+
+const (
+	ImportStd        ImportKind = iota // 標準ライブラリ
+	ImportInternal                     // 同じモジュールのパッケージ
+	ImportThirdParty                   // 外部モジュールのパッケージ
+)
+
+// ClassifyImport はimportパスを、モジュールmodulePathのパッケージから見た種類に分類する
+// 先頭の要素にドットを含まないパスを標準ライブラリとみなす。modulePathが空なら同じモジュールとは判定しない
+func ClassifyImport(importPath, modulePath string) ImportKind {
+	if modulePath != "" && (importPath == modulePath || strings.HasPrefix(importPath, modulePath+"/")) {
+		return ImportInternal
+	}
+	first, _, _ := strings.Cut(importPath, "/")
+	if !strings.Contains(first, ".") {
+		return ImportStd
+	}
+	return ImportThirdParty
+}
@@ -0,0 +1,237 @@
+// Package policy は設定ファイルのimportルールをコンパイルし、analyzerを実行せずに判定するためのパッケージだ
+//
+// コード生成ツールやレビューボットなどから「ファイルYでXをimportしてよいか、どのルールによるか」を問い合わせるのに使う
+//
+//	p, err := policy.Load(".llinter.yaml")
+//	if err != nil {
+//		return err
+//	}
+//	d := p.Check(config.File{Path: "internal/api/handler.go"}, "example.com/app/internal/api", "fmt")
+//	if !d.Allowed {
+//		fmt.Printf("denied by rules[%d] (%s)\n", d.RuleIndex, d.Pattern)
+//	}
+package policy
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/blck-snwmn/dependencylintgo/analyzer/config"
+)
+
+// Policy は設定のルールをコンパイルしたものだ
+type Policy struct {
+	rules        *config.RuleSet
+	compiled     []*Rule
+	deprecations []deprecation
+	exceptions   []exception
+	generated    string // 生成コードの扱いの既定値。非推奨のimportのチェックに使う
+}
+
+// Rule はコンパイル済みのルールだ
+type Rule struct {
+	Index  int          // 設定のrules中の位置
+	Config *config.Rule // 元になった設定
+
+	deny       config.Patterns
+	allow      config.Patterns
+	allowBlank config.Patterns
+	aliases    []alias
+}
+
+// alias はrequire_aliasの1項目だ
+type alias struct {
+	patterns config.Patterns
+	name     string
+}
+
+// Decision はimportの可否の判定結果だ
+type Decision struct {
+	Allowed     bool         // importしてよいか
+	Rule        *config.Rule // 適用されたルール。一致するルールがなければnil
+	RuleIndex   int          // 適用されたルールの設定のrules中の位置。一致するルールがなければ-1
+	Pattern     string       // 判定を決めたdeny/allowのパターン。どちらにも一致しなければ空
+	Severity    string       // 違反の重大度（error|warning）
+	Denied      bool         // ルールのdenyで禁止されているか。期限内の例外で許可されてもtrueになる
+	Exception   *Exception   // 禁止されたimportに一致した例外。なければnil
+	Deprecation *Deprecation // 非推奨のimportの判定。非推奨でなければnil
+}
+
+// New は設定からPolicyを作る
+func New(cfg *config.Config) *Policy {
+	p := &Policy{rules: config.CompileRules(cfg)}
+	if cfg == nil {
+		return p
+	}
+
+	p.compiled = make([]*Rule, len(cfg.Rules))
+	for i := range cfg.Rules {
+		p.compiled[i] = compileRule(i, &cfg.Rules[i])
+	}
+	p.deprecations = compileDeprecations(cfg)
+	p.generated = cfg.Generated
+	p.exceptions = compileExceptions(cfg)
+	return p
+}
+
+// Load は設定ファイルを読み込んでPolicyを作る
+func Load(configPath string) (*Policy, error) {
+	cfg, err := config.LoadConfig(configPath)
+	if err != nil {
+		return nil, err
+	}
+	return New(cfg), nil
+}
+
+func compileRule(index int, rule *config.Rule) *Rule {
+	r := &Rule{
+		Index:      index,
+		Config:     rule,
+		deny:       config.CompileImportPatterns(rule.Deny),
+		allow:      config.CompileImportPatterns(rule.Allow),
+		allowBlank: config.CompileImportPatterns(rule.AllowBlankImports),
+	}
+
+	// 複数のパターンに一致する場合はパターンの辞書順で最初のものを使う
+	patterns := make([]string, 0, len(rule.RequireAlias))
+	for pattern := range rule.RequireAlias {
+		patterns = append(patterns, pattern)
+	}
+	sort.Strings(patterns)
+	for _, pattern := range patterns {
+		r.aliases = append(r.aliases, alias{
+			patterns: config.CompileImportPatterns([]string{pattern}),
+			name:     rule.RequireAlias[pattern],
+		})
+	}
+
+	return r
+}
+
+// RuleFor はファイルに適用するルールを返す
+// 最初に一致したルールが使われる。一致するルールがなければnilを返す
+func (p *Policy) RuleFor(file config.File) *Rule {
+	_, index := p.rules.Match(file)
+	if index < 0 {
+		return nil
+	}
+	return p.compiled[index]
+}
+
+// Check はパッケージpkgのファイルでimportPathをimportしてよいか、今日の日付で判定する
+// pkgが外部テストパッケージ（importパスが_testで終わる）の場合、fileは外部テストのファイルとして扱う
+func (p *Policy) Check(file config.File, pkg, importPath string) Decision {
+	return p.CheckAt(file, pkg, importPath, config.Today())
+}
+
+// CheckAt はtodayの時点でCheckと同じ判定を行う
+func (p *Policy) CheckAt(file config.File, pkg, importPath string, today config.Date) Decision {
+	file = packageFile(file, pkg)
+	return p.decide(p.rulesFor(file), file, pkg, importPath, today)
+}
+
+// rulesFor はファイルに適用するルールと、上位のディレクトリの設定ファイルから引き継ぐルールを返す
+func (p *Policy) rulesFor(file config.File) []*Rule {
+	indices := p.rules.MatchInherited(file)
+	rules := make([]*Rule, len(indices))
+	for i, index := range indices {
+		rules[i] = p.compiled[index]
+	}
+	return rules
+}
+
+// packageFile はpkgが外部テストパッケージなら、fileを外部テストのファイルにする
+func packageFile(file config.File, pkg string) config.File {
+	if strings.HasSuffix(pkg, "_test") {
+		file.IsTest = true
+		file.ExternalTest = true
+	}
+	return file
+}
+
+// decide はルールの判定に例外と非推奨のimportを加えて、最終的な判定を求める
+// rulesは適用するルールと引き継ぐルールの順に並ぶ。引き継いだルールはdenyで禁止する場合だけ判定に使う
+// ルールのdenyで禁止されても期限内の例外（exceptions）に一致すれば許可する
+// 期限を過ぎた非推奨のimport（deprecate）はルールに関係なく禁止する
+func (p *Policy) decide(rules []*Rule, file config.File, pkg, importPath string, today config.Date) Decision {
+	d := Decision{Allowed: true, RuleIndex: -1}
+	for i, rule := range rules {
+		if rd := rule.CheckImport(importPath); i == 0 || rd.Denied {
+			d = rd
+		}
+		if d.Denied {
+			break
+		}
+	}
+
+	if d.Denied {
+		if e, ok := p.ExceptionFor(file, pkg, importPath, today); ok {
+			d.Exception = &e
+			d.Allowed = !e.Expired
+		}
+	}
+	if dep, ok := p.Deprecated(file, importPath, today); ok {
+		d.Deprecation = &dep
+		if dep.Expired {
+			d.Allowed = false
+		}
+	}
+	return d
+}
+
+// Severity はルールの違反の重大度を返す
+func (r *Rule) Severity() string {
+	if r.Config.Severity == "" {
+		return config.SeverityError
+	}
+	return r.Config.Severity
+}
+
+// CheckImport はこのルールの下でimportPathをimportしてよいか判定する
+// denyに一致してもallowに一致すれば許可される
+func (r *Rule) CheckImport(importPath string) Decision {
+	d := Decision{
+		Allowed:   true,
+		Rule:      r.Config,
+		RuleIndex: r.Index,
+		Severity:  r.Severity(),
+	}
+
+	denied, ok := r.deny.Match(importPath)
+	if !ok {
+		return d
+	}
+	if allowed, ok := r.allow.Match(importPath); ok {
+		d.Pattern = allowed
+		return d
+	}
+
+	d.Allowed = false
+	d.Denied = true
+	d.Pattern = denied
+	return d
+}
+
+// BlankImportAllowed はimportPathのブランクimportが許可されているか確認する
+func (r *Rule) BlankImportAllowed(importPath string) bool {
+	if !r.Config.DenyBlankImports {
+		return true
+	}
+	_, ok := r.allowBlank.Match(importPath)
+	return ok
+}
+
+// RequiredAlias はimportPathに必須の別名を返す
+func (r *Rule) RequiredAlias(importPath string) (string, bool) {
+	for _, a := range r.aliases {
+		if _, ok := a.patterns.Match(importPath); ok {
+			return a.name, true
+		}
+	}
+	return "", false
+}
+
+// SymbolDenied はパッケージpkgPathのシンボルnameの使用が禁止されているか確認する
+func (r *Rule) SymbolDenied(pkgPath, name string) bool {
+	return config.IsSymbolMatched(pkgPath, name, r.Config.DenySymbols)
+}
@@ -0,0 +1,345 @@
+package policy_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/blck-snwmn/dependencylintgo/analyzer/config"
+	"github.com/blck-snwmn/dependencylintgo/analyzer/policy"
+)
+
+func TestPolicyCheck(t *testing.T) {
+	cfg := &config.Config{
+		Rules: []config.Rule{
+			{
+				Path:     []string{"internal/legacy/*.go"},
+				Deny:     []string{"os"},
+				Severity: config.SeverityWarning,
+			},
+			{
+				Path:  []string{"internal/**/*.go"},
+				Deny:  []string{"fmt", "github.com/forbidden/**"},
+				Allow: []string{"github.com/forbidden/ok"},
+			},
+			{
+				Path:  []string{"internal/**/*.go"},
+				Tests: config.TestsOnly,
+				Deny:  []string{"net/http"},
+			},
+		},
+		Deprecate: []config.Deprecation{
+			{Import: "github.com/old/log", Until: date(t, "2026-12-31")},
+			{Import: "github.com/old/**", Until: date(t, "2026-06-30")},
+		},
+		Exceptions: []config.Exception{
+			{Path: []string{"internal/api/handler.go"}, Import: "fmt", Owner: "@team-a", Expires: date(t, "2026-12-31")},
+			{Packages: []string{"example.com/app/internal/legacy"}, Import: "os", Owner: "@team-b", Expires: date(t, "2026-06-30")},
+		},
+	}
+	p := policy.New(cfg)
+
+	tests := []struct {
+		name       string
+		file       string
+		pkg        string
+		importPath string
+		want       policy.Decision
+	}{
+		{
+			name:       "denyに一致",
+			file:       "internal/api/handler.go",
+			pkg:        "example.com/app/internal/api",
+			importPath: "github.com/forbidden/pkg",
+			want:       policy.Decision{Allowed: false, Rule: &cfg.Rules[1], RuleIndex: 1, Pattern: "github.com/forbidden/**", Severity: config.SeverityError, Denied: true},
+		},
+		{
+			name:       "allowで許可",
+			file:       "internal/api/handler.go",
+			pkg:        "example.com/app/internal/api",
+			importPath: "github.com/forbidden/ok",
+			want:       policy.Decision{Allowed: true, Rule: &cfg.Rules[1], RuleIndex: 1, Pattern: "github.com/forbidden/ok", Severity: config.SeverityError},
+		},
+		{
+			name:       "どのパターンにも一致しない",
+			file:       "internal/api/handler.go",
+			pkg:        "example.com/app/internal/api",
+			importPath: "strings",
+			want:       policy.Decision{Allowed: true, Rule: &cfg.Rules[1], RuleIndex: 1, Severity: config.SeverityError},
+		},
+		{
+			name:       "警告のルール",
+			file:       "internal/legacy/old.go",
+			pkg:        "example.com/app/internal/legacy",
+			importPath: "os",
+			want: policy.Decision{
+				Allowed: false, Rule: &cfg.Rules[0], RuleIndex: 0, Pattern: "os", Severity: config.SeverityWarning, Denied: true,
+				Exception: &policy.Exception{Index: 1, Config: &cfg.Exceptions[1], Expired: true},
+			},
+		},
+		{
+			name:       "外部テストパッケージ",
+			file:       "internal/api/handler_test.go",
+			pkg:        "example.com/app/internal/api_test",
+			importPath: "fmt",
+			want:       policy.Decision{Allowed: false, Rule: &cfg.Rules[1], RuleIndex: 1, Pattern: "fmt", Severity: config.SeverityError, Denied: true},
+		},
+		{
+			name:       "一致するルールがない",
+			file:       "cmd/app/main.go",
+			pkg:        "example.com/app/cmd/app",
+			importPath: "fmt",
+			want:       policy.Decision{Allowed: true, RuleIndex: -1},
+		},
+		{
+			name:       "期限内の例外で許可",
+			file:       "internal/api/handler.go",
+			pkg:        "example.com/app/internal/api",
+			importPath: "fmt",
+			want: policy.Decision{
+				Allowed: true, Rule: &cfg.Rules[1], RuleIndex: 1, Pattern: "fmt", Severity: config.SeverityError, Denied: true,
+				Exception: &policy.Exception{Index: 0, Config: &cfg.Exceptions[0]},
+			},
+		},
+		{
+			name:       "期限前の非推奨は許可",
+			file:       "cmd/app/main.go",
+			pkg:        "example.com/app/cmd/app",
+			importPath: "github.com/old/log",
+			want: policy.Decision{
+				Allowed: true, RuleIndex: -1,
+				Deprecation: &policy.Deprecation{Index: 0, Config: &cfg.Deprecate[0], Severity: config.SeverityWarning},
+			},
+		},
+		{
+			name:       "期限を過ぎた非推奨はルールに関係なく禁止",
+			file:       "cmd/app/main.go",
+			pkg:        "example.com/app/cmd/app",
+			importPath: "github.com/old/http",
+			want: policy.Decision{
+				Allowed: false, RuleIndex: -1,
+				Deprecation: &policy.Deprecation{Index: 1, Config: &cfg.Deprecate[1], Expired: true, Severity: config.SeverityError},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := p.CheckAt(config.File{Path: tt.file}, tt.pkg, tt.importPath, date(t, "2026-07-01"))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CheckAt() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// date はテスト用に日付を解析する
+func date(t *testing.T, s string) config.Date {
+	t.Helper()
+	d, err := config.ParseDate(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+
+func TestRule(t *testing.T) {
+	p := policy.New(&config.Config{
+		Rules: []config.Rule{
+			{
+				Path:              []string{"**/*.go"},
+				DenySymbols:       []string{"time.Now"},
+				RequireAlias:      map[string]string{"example.com/client/**": "client", "example.com/client/v2": "clientv2"},
+				DenyBlankImports:  true,
+				AllowBlankImports: []string{"example.com/driver"},
+			},
+		},
+	})
+
+	rule := p.RuleFor(config.File{Path: "app/main.go"})
+	if rule == nil {
+		t.Fatal("Expected a matching rule")
+	}
+
+	if alias, ok := rule.RequiredAlias("example.com/client/v2"); !ok || alias != "client" {
+		t.Errorf("RequiredAlias() = %q, %v, want %q, true", alias, ok, "client")
+	}
+	if _, ok := rule.RequiredAlias("example.com/server"); ok {
+		t.Error("Expected no required alias")
+	}
+	if !rule.BlankImportAllowed("example.com/driver") {
+		t.Error("Expected blank import of example.com/driver to be allowed")
+	}
+	if rule.BlankImportAllowed("example.com/other") {
+		t.Error("Expected blank import of example.com/other to be denied")
+	}
+	if !rule.SymbolDenied("time", "Now") || rule.SymbolDenied("time", "Since") {
+		t.Error("Expected only time.Now to be denied")
+	}
+	if rule.Severity() != config.SeverityError {
+		t.Errorf("Severity() = %q, want %q", rule.Severity(), config.SeverityError)
+	}
+}
+
+func TestPolicyExplain(t *testing.T) {
+	p := policy.New(&config.Config{
+		Rules: []config.Rule{
+			{
+				Path:  []string{"internal/**/*.go"},
+				Tests: config.TestsExclude,
+				Deny:  []string{"**"},
+			},
+			{
+				Path:  []string{"internal/**/*.go"},
+				Deny:  []string{"github.com/**"},
+				Allow: []string{"github.com/approved/*"},
+			},
+		},
+	})
+
+	e := p.Explain(config.File{Path: "internal/api/handler_test.go", IsTest: true}, "", "github.com/approved/pkg")
+
+	if len(e.Rules) != 2 {
+		t.Fatalf("Expected 2 rules to be considered, got %d", len(e.Rules))
+	}
+	if m := e.Rules[0]; m.Applied || m.PathPattern != "internal/**/*.go" || m.Reason == "" {
+		t.Errorf("Expected rules[0] to be skipped by tests, got %+v", m)
+	}
+	if m := e.Rules[1]; !m.Applied {
+		t.Errorf("Expected rules[1] to be applied, got %+v", m)
+	}
+	if e.Deny != "github.com/**" || e.Allow != "github.com/approved/*" {
+		t.Errorf("Expected deny %q and allow %q, got %q and %q", "github.com/**", "github.com/approved/*", e.Deny, e.Allow)
+	}
+	if !e.Decision.Allowed || e.Decision.RuleIndex != 1 {
+		t.Errorf("Expected allowed by rules[1], got %+v", e.Decision)
+	}
+}
+
+func TestUsage(t *testing.T) {
+	p := policy.New(&config.Config{
+		Rules: []config.Rule{
+			{
+				Path:  []string{"internal/**/*.go", "pkg/**/*.go"},
+				Deny:  []string{"fmt", "github.com/old/**"},
+				Allow: []string{"fmt", "os"},
+			},
+			{
+				Path: []string{"legacy/**/*.go"},
+				Deny: []string{"net/http"},
+			},
+			{
+				Path: []string{"cmd/**/*.go"},
+				Deny: []string{"log"},
+			},
+		},
+	})
+
+	usage := p.NewUsage()
+	usage.AddFile(config.File{Path: "internal/api/handler.go"}, "example.com/app/internal/api", []string{"fmt", "os"})
+	usage.AddFile(config.File{Path: "cmd/app/main.go"}, "example.com/app/cmd/app", []string{"log"})
+
+	unused := usage.Unused()
+	if len(unused) != 2 {
+		t.Fatalf("Expected 2 rules with unused patterns, got %+v", unused)
+	}
+
+	r := unused[0]
+	if r.Index != 0 || r.NoFile {
+		t.Errorf("Expected rules[0] to match files, got %+v", r)
+	}
+	if want := []string{"pkg/**/*.go"}; !reflect.DeepEqual(r.Paths, want) {
+		t.Errorf("Expected unused paths %v, got %v", want, r.Paths)
+	}
+	if want := []string{"github.com/old/**"}; !reflect.DeepEqual(r.Deny, want) {
+		t.Errorf("Expected unused deny %v, got %v", want, r.Deny)
+	}
+	// osはどのdenyにも一致しないので上書きしていない
+	if want := []string{"os"}; !reflect.DeepEqual(r.Allow, want) {
+		t.Errorf("Expected unused allow %v, got %v", want, r.Allow)
+	}
+
+	if r := unused[1]; r.Index != 1 || !r.NoFile || r.Deny != nil {
+		t.Errorf("Expected rules[1] to match no file, got %+v", r)
+	}
+}
+
+func TestPolicyDeprecated(t *testing.T) {
+	p := policy.New(&config.Config{
+		Deprecate: []config.Deprecation{
+			{Import: "github.com/old/log", Until: date(t, "2026-12-31"), ReplaceWith: "log/slog"},
+			{Import: "github.com/old/**", Until: date(t, "2026-06-30")},
+		},
+	})
+
+	tests := []struct {
+		name         string
+		importPath   string
+		today        string
+		wantOK       bool
+		wantIndex    int
+		wantSeverity string
+	}{
+		{
+			name:         "期限前は警告",
+			importPath:   "github.com/old/log",
+			today:        "2026-12-31",
+			wantOK:       true,
+			wantIndex:    0,
+			wantSeverity: config.SeverityWarning,
+		},
+		{
+			name:         "期限を過ぎたらエラー",
+			importPath:   "github.com/old/log",
+			today:        "2027-01-01",
+			wantOK:       true,
+			wantIndex:    0,
+			wantSeverity: config.SeverityError,
+		},
+		{
+			name:         "パターンに一致",
+			importPath:   "github.com/old/http",
+			today:        "2026-07-01",
+			wantOK:       true,
+			wantIndex:    1,
+			wantSeverity: config.SeverityError,
+		},
+		{
+			name:       "非推奨ではない",
+			importPath: "log/slog",
+			today:      "2026-07-01",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, ok := p.Deprecated(config.File{Path: "main.go"}, tt.importPath, date(t, tt.today))
+			if ok != tt.wantOK {
+				t.Fatalf("Deprecated() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if d.Index != tt.wantIndex || d.Severity != tt.wantSeverity || d.Expired != (tt.wantSeverity == config.SeverityError) {
+				t.Errorf("Deprecated() = %+v, want index %d, severity %s", d, tt.wantIndex, tt.wantSeverity)
+			}
+		})
+	}
+
+	// 期限の早い順に並ぶ
+	schedule := p.Schedule(date(t, "2026-07-01"))
+	if len(schedule) != 2 || schedule[0].Index != 1 || !schedule[0].Expired || schedule[1].Index != 0 || schedule[1].Expired {
+		t.Errorf("Unexpected schedule: %+v", schedule)
+	}
+
+	// ルールと同じく、設定全体のgeneratedで対象外になるファイルは判定しない
+	skip := policy.New(&config.Config{
+		Generated: config.GeneratedSkip,
+		Deprecate: []config.Deprecation{{Import: "github.com/old/log", Until: date(t, "2026-12-31")}},
+	})
+	if d, ok := skip.Deprecated(config.File{Path: "gen.go", Generated: true}, "github.com/old/log", date(t, "2026-07-01")); ok {
+		t.Errorf("Expected generated file to be skipped, got %+v", d)
+	}
+	if _, ok := skip.Deprecated(config.File{Path: "main.go"}, "github.com/old/log", date(t, "2026-07-01")); !ok {
+		t.Error("Expected non-generated file to be checked")
+	}
+}
@@ -0,0 +1,149 @@
+package policy
+
+import (
+	"github.com/blck-snwmn/dependencylintgo/analyzer/config"
+)
+
+// Usage は複数のファイルにわたって、ルールとパターンが使われたかを集計する
+type Usage struct {
+	policy     *Policy
+	rules      []ruleUsage
+	exceptions []bool // 例外が禁止されたimportに一致したか
+}
+
+// ruleUsage は1つのルールのパターンごとの使用状況だ
+type ruleUsage struct {
+	paths []patternUsage
+	deny  []patternUsage
+	allow []patternUsage
+}
+
+// patternUsage は1つのパターンの使用状況だ
+type patternUsage struct {
+	pattern string
+	matcher config.Patterns
+	used    bool
+}
+
+// UnusedRule は使われなかったパターンを持つルールだ
+type UnusedRule struct {
+	Index  int          // 設定のrules中の位置
+	Rule   *config.Rule // ルールの設定
+	NoFile bool         // pathがどのファイルにも一致しなかったか
+	Paths  []string     // どのファイルにも一致しなかったpathパターン
+	Deny   []string     // どのimportにも一致しなかったdenyパターン
+	Allow  []string     // denyを上書きしなかったallowパターン
+}
+
+// UnusedException はルールで禁止されたどのimportにも一致しなかった例外だ
+type UnusedException struct {
+	Index  int               // 設定のexceptions中の位置
+	Config *config.Exception // 例外の設定
+}
+
+// NewUsage は使用状況の集計を始める
+func (p *Policy) NewUsage() *Usage {
+	u := &Usage{
+		policy:     p,
+		rules:      make([]ruleUsage, len(p.compiled)),
+		exceptions: make([]bool, len(p.exceptions)),
+	}
+	for i, rule := range p.compiled {
+		u.rules[i] = ruleUsage{
+			paths: newPatternUsages(rule.Config.Path, config.CompileFilePatterns),
+			deny:  newPatternUsages(rule.Config.Deny, config.CompileImportPatterns),
+			allow: newPatternUsages(rule.Config.Allow, config.CompileImportPatterns),
+		}
+	}
+	return u
+}
+
+func newPatternUsages(patterns []string, compile func([]string) config.Patterns) []patternUsage {
+	usages := make([]patternUsage, len(patterns))
+	for i, pattern := range patterns {
+		usages[i] = patternUsage{pattern: pattern, matcher: compile([]string{pattern})}
+	}
+	return usages
+}
+
+// mark はsに一致するパターンを使用済みにし、一致したものがあったか返す
+func mark(usages []patternUsage, s string) bool {
+	matched := false
+	for i := range usages {
+		if _, ok := usages[i].matcher.Match(s); ok {
+			usages[i].used = true
+			matched = true
+		}
+	}
+	return matched
+}
+
+// AddFile はパッケージpkgのファイルとそのimportを集計に加える
+// pathパターンは適用されるかに関わらず一致したかで数え、deny/allowは適用されたルールについてだけ数える
+// 例外はCheckの判定で禁止されたimportに一致したかで数える
+func (u *Usage) AddFile(file config.File, pkg string, imports []string) {
+	for i := range u.rules {
+		mark(u.rules[i].paths, file.Path)
+	}
+
+	rule := u.policy.RuleFor(file)
+	if rule == nil {
+		return
+	}
+	usage := &u.rules[rule.Index]
+	for _, importPath := range imports {
+		if d := u.policy.Check(file, pkg, importPath); d.Exception != nil {
+			u.exceptions[d.Exception.Index] = true
+		}
+		if !mark(usage.deny, importPath) {
+			continue
+		}
+		mark(usage.allow, importPath)
+	}
+}
+
+// Unused は使われなかったパターンを持つルールを返す
+// pathがどのファイルにも一致しなかったルールはdeny/allowも使われようがないので、NoFileだけを設定する
+func (u *Usage) Unused() []UnusedRule {
+	var unused []UnusedRule
+	for i, usage := range u.rules {
+		r := UnusedRule{
+			Index: i,
+			Rule:  u.policy.compiled[i].Config,
+			Paths: unusedPatterns(usage.paths),
+		}
+		if len(r.Paths) == len(usage.paths) {
+			r.NoFile = true
+			r.Paths = nil
+		} else {
+			r.Deny = unusedPatterns(usage.deny)
+			r.Allow = unusedPatterns(usage.allow)
+		}
+
+		if r.NoFile || len(r.Paths) > 0 || len(r.Deny) > 0 || len(r.Allow) > 0 {
+			unused = append(unused, r)
+		}
+	}
+	return unused
+}
+
+// UnusedExceptions はルールで禁止されたどのimportにも一致しなかった例外を返す
+func (u *Usage) UnusedExceptions() []UnusedException {
+	var unused []UnusedException
+	for i, used := range u.exceptions {
+		if !used {
+			unused = append(unused, UnusedException{Index: i, Config: u.policy.exceptions[i].config})
+		}
+	}
+	return unused
+}
+
+func unusedPatterns(usages []patternUsage) []string {
+	var patterns []string
+	for _, usage := range usages {
+		if !usage.used {
+			patterns = append(patterns, usage.pattern)
+		}
+	}
+	return patterns
+}
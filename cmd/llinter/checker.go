@@ -0,0 +1,153 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/blck-snwmn/dependencylintgo/analyzer/config"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/checker"
+	"golang.org/x/tools/go/packages"
+)
+
+// checkerFlags はanalyzerを実行するときのフラグだ
+type checkerFlags struct {
+	json  bool
+	tests bool
+}
+
+// newCheckerFlagSet はanalyzerを実行するときのフラグを登録したFlagSetを返す
+// analyzerのフラグはmulticheckerと同じく「analyzer名.フラグ名」でも指定できる
+func newCheckerFlagSet(stderr io.Writer) (*flag.FlagSet, *checkerFlags) {
+	fs := flag.NewFlagSet("llinter", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: llinter [flags] [packages]")
+		fs.PrintDefaults()
+	}
+
+	var f checkerFlags
+	fs.BoolVar(&f.json, "json", false, "emit JSON output")
+	fs.BoolVar(&f.tests, "test", true, "indicates whether test files should be analyzed, too")
+	fs.Var(sharedFlag{name: "config"}, "config", "configuration file path (default .llinter.yaml)")
+	fs.Var(sharedFlag{name: "verbose", isBool: true}, "verbose", "report which configuration file is used")
+	fs.Var(sharedFlag{name: "hierarchical", isBool: true}, "hierarchical", "discover .llinter.yaml files from the module root down to each package directory")
+
+	// 以下はmainでanalyzerを実行する前に取り除くフラグで、ヘルプに表示するためだけに登録する
+	fs.Var(&targetList{}, "target", "analyze for `GOOS[/GOARCH][:tag,...]` (repeatable)")
+	fs.String("print-effective-config", "", "print the merged hierarchical config for `dir` and exit")
+	fs.Bool("report-unused-rules", false, "report rules and patterns that no analyzed file or import uses, instead of running the analyzers")
+
+	for _, a := range analyzers {
+		a.Flags.VisitAll(func(af *flag.Flag) {
+			fs.Var(af.Value, a.Name+"."+af.Name, af.Usage)
+		})
+	}
+	return fs, &f
+}
+
+// runChecker はパッケージを読み込んでanalyzerを実行し、診断を表示して終了コードを返す
+//
+// multicheckerは重大度に関係なく診断があれば終了コード3で終わるので、analyzerはcheckerで直接実行し、
+// エラーの診断（Categoryがwarningではない診断）がある場合だけ終了コード3を返す
+// パッケージの読み込みや解析に失敗した場合は1、-jsonを指定した場合は診断があっても0を返す
+func runChecker(args, env []string, stdout, stderr io.Writer) int {
+	fs, flags := newCheckerFlagSet(stderr)
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	patterns := fs.Args()
+	if len(patterns) == 0 {
+		fs.Usage()
+		return 2
+	}
+
+	mode := packages.LoadSyntax | packages.NeedModule
+	if needFacts(analyzers) {
+		mode |= packages.NeedDeps
+	}
+	pkgs, err := packages.Load(&packages.Config{Mode: mode, Env: env, Tests: flags.tests}, patterns...)
+	if err != nil {
+		fmt.Fprintf(stderr, "llinter: %v\n", err)
+		return 1
+	}
+	if len(pkgs) == 0 {
+		fmt.Fprintf(stderr, "llinter: %s matched no packages\n", strings.Join(patterns, " "))
+		return 1
+	}
+	if printLoadErrors(stderr, pkgs) > 0 {
+		return 1
+	}
+
+	graph, err := checker.Analyze(analyzers, pkgs, nil)
+	if err != nil {
+		fmt.Fprintf(stderr, "llinter: %v\n", err)
+		return 1
+	}
+
+	if flags.json {
+		if err := graph.PrintJSON(stdout); err != nil {
+			fmt.Fprintf(stderr, "llinter: %v\n", err)
+			return 1
+		}
+		return 0
+	}
+	if err := graph.PrintText(stderr, -1); err != nil {
+		fmt.Fprintf(stderr, "llinter: %v\n", err)
+		return 1
+	}
+	return exitCode(graph)
+}
+
+// needFacts はanalyzerが依存するパッケージの解析結果（fact）を使うか確認する
+func needFacts(analyzers []*analysis.Analyzer) bool {
+	seen := make(map[*analysis.Analyzer]bool)
+	var visit func(as []*analysis.Analyzer) bool
+	visit = func(as []*analysis.Analyzer) bool {
+		for _, a := range as {
+			if seen[a] {
+				continue
+			}
+			seen[a] = true
+			if len(a.FactTypes) > 0 || visit(a.Requires) {
+				return true
+			}
+		}
+		return false
+	}
+	return visit(analyzers)
+}
+
+// printLoadErrors はパッケージとその依存の読み込みエラーを表示し、その数を返す
+func printLoadErrors(w io.Writer, pkgs []*packages.Package) int {
+	n := 0
+	packages.Visit(pkgs, nil, func(pkg *packages.Package) {
+		for _, err := range pkg.Errors {
+			fmt.Fprintln(w, err)
+			n++
+		}
+	})
+	return n
+}
+
+// exitCode は解析の結果から終了コードを求める
+// 解析に失敗したanalyzerがあれば1、エラーの診断があれば3、警告の診断だけなら0になる
+func exitCode(graph *checker.Graph) int {
+	code := 0
+	for act := range graph.All() {
+		if act.Err != nil {
+			return 1
+		}
+		if !act.IsRoot {
+			continue
+		}
+		for _, diag := range act.Diagnostics {
+			if diag.Category != config.SeverityWarning {
+				code = 3
+			}
+		}
+	}
+	return code
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestRunChecker(t *testing.T) {
+	tests := []struct {
+		name       string
+		config     string
+		wantCode   int
+		wantOutput string
+	}{
+		{
+			name: "期限前の非推奨は警告なので成功",
+			config: `deprecate:
+  - import: example.com/app/oldlog
+    until: 2999-12-31
+`,
+			wantCode:   0,
+			wantOutput: `[warning] import "example.com/app/oldlog" is deprecated`,
+		},
+		{
+			name: "期限を過ぎた非推奨はエラー",
+			config: `deprecate:
+  - import: example.com/app/oldlog
+    until: 2000-01-01
+`,
+			wantCode:   3,
+			wantOutput: `import "example.com/app/oldlog" was deprecated until 2000-01-01`,
+		},
+		{
+			name: "重大度が警告のルールの違反は成功",
+			config: `rules:
+  - path: ["*.go"]
+    deny: ["example.com/app/oldlog"]
+    severity: warning
+`,
+			wantCode:   0,
+			wantOutput: `[warning] import "example.com/app/oldlog" is not allowed`,
+		},
+		{
+			name: "エラーの違反",
+			config: `rules:
+  - path: ["*.go"]
+    deny: ["example.com/app/oldlog"]
+`,
+			wantCode:   3,
+			wantOutput: `import "example.com/app/oldlog" is not allowed`,
+		},
+		{
+			name:     "違反なし",
+			config:   "rules: []\n",
+			wantCode: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := writeTestModule(t, map[string]string{
+				"go.mod":           "module example.com/app\n\ngo 1.22\n",
+				".llinter.yaml":    tt.config,
+				"oldlog/oldlog.go": "package oldlog\n\nfunc Print() {}\n",
+				"main.go":          "package main\n\nimport \"example.com/app/oldlog\"\n\nfunc main() { oldlog.Print() }\n",
+			})
+			t.Chdir(dir)
+
+			var stderr bytes.Buffer
+			code := runChecker([]string{"./..."}, os.Environ(), io.Discard, &stderr)
+			if code != tt.wantCode {
+				t.Errorf("runChecker() = %d, want %d\n%s", code, tt.wantCode, stderr.String())
+			}
+			if !strings.Contains(stderr.String(), tt.wantOutput) {
+				t.Errorf("Expected output to contain %q, got:\n%s", tt.wantOutput, stderr.String())
+			}
+		})
+	}
+
+	t.Run("-jsonは診断があっても成功", func(t *testing.T) {
+		dir := writeTestModule(t, map[string]string{
+			"go.mod":           "module example.com/app\n\ngo 1.22\n",
+			".llinter.yaml":    "rules:\n  - path: [\"*.go\"]\n    deny: [\"example.com/app/oldlog\"]\n",
+			"oldlog/oldlog.go": "package oldlog\n\nfunc Print() {}\n",
+			"main.go":          "package main\n\nimport \"example.com/app/oldlog\"\n\nfunc main() { oldlog.Print() }\n",
+		})
+		t.Chdir(dir)
+
+		var stdout bytes.Buffer
+		if code := runChecker([]string{"-json", "./..."}, os.Environ(), &stdout, io.Discard); code != 0 {
+			t.Errorf("runChecker() = %d, want 0", code)
+		}
+		if !strings.Contains(stdout.String(), `"category": "error"`) {
+			t.Errorf("Expected JSON diagnostics, got:\n%s", stdout.String())
+		}
+	})
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/blck-snwmn/dependencylintgo/analyzer/config"
+)
+
+// runConfig は設定ファイルを扱うサブコマンドを実行する
+func runConfig(args []string) int {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, "usage: llinter config migrate [-w] <file>")
+		return 2
+	}
+
+	switch args[0] {
+	case "migrate":
+		return runConfigMigrate(args[1:])
+	}
+	fmt.Fprintf(os.Stderr, "llinter: unknown config command %q\n", args[0])
+	return 2
+}
+
+// runConfigMigrate は古い形式の設定ファイルを最新の形式に書き換える
+func runConfigMigrate(args []string) int {
+	fs := flag.NewFlagSet("config migrate", flag.ContinueOnError)
+	write := fs.Bool("w", false, "write result to the file instead of stdout")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: llinter config migrate [-w] <file>")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return 2
+	}
+	path := fs.Arg(0)
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json", ".toml":
+		fmt.Fprintf(os.Stderr, "llinter: %s: migrate supports only YAML config files\n", path)
+		return 1
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "llinter: %v\n", err)
+		return 1
+	}
+
+	migrated, err := config.Migrate(data)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "llinter: %s: %v\n", path, err)
+		return 1
+	}
+
+	if !*write {
+		os.Stdout.Write(migrated)
+		return 0
+	}
+	if err := os.WriteFile(path, migrated, 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "llinter: %v\n", err)
+		return 1
+	}
+	return 0
+}
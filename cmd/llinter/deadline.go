@@ -0,0 +1,91 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/blck-snwmn/dependencylintgo/analyzer/config"
+	"github.com/blck-snwmn/dependencylintgo/analyzer/policy"
+)
+
+// deadlineList は期限のある設定（deprecateやexceptions）を一覧するサブコマンドだ
+type deadlineList struct {
+	name   string // フラグのエラーで表示するサブコマンド名
+	kind   string // 不明な出力形式のエラーで表示する一覧の種類
+	usage  string
+	table  func(w io.Writer, pol *policy.Policy, today config.Date) error // 表形式で表示する
+	encode func(w io.Writer, pol *policy.Policy, today config.Date) error // JSONで出力する
+}
+
+// run はフラグを読み、設定を読み込んで、-formatに応じた形式で一覧を表示する
+func (l deadlineList) run(args []string) int {
+	fs := flag.NewFlagSet(l.name, flag.ContinueOnError)
+	configFile := fs.String("config", config.DefaultConfigName, "configuration file path")
+	format := fs.String("format", "table", "output format (table or json)")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), l.usage)
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if *format != "table" && *format != "json" {
+		fmt.Fprintf(os.Stderr, "llinter: unknown %s format %q\n", l.kind, *format)
+		return 2
+	}
+
+	pol, err := policy.Load(*configFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "llinter: %v\n", err)
+		return 1
+	}
+
+	today := config.Today()
+	if *format == "json" {
+		err = l.encode(os.Stdout, pol, today)
+	} else {
+		err = l.table(os.Stdout, pol, today)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "llinter: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// deadlineStatus は期限までの残りの日数か、期限切れであることを表す
+func deadlineStatus(deadline config.Date, expired bool, today config.Date) string {
+	if expired {
+		return "expired"
+	}
+	switch days := today.DaysUntil(deadline); days {
+	case 0:
+		return "last day"
+	case 1:
+		return "1 day left"
+	default:
+		return fmt.Sprintf("%d days left", days)
+	}
+}
+
+// printTable は見出しと行をタブ区切りの表にそろえて表示する
+func printTable(w io.Writer, header []string, rows [][]string) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+// encodeList は一覧を字下げしたJSONで出力する
+func encodeList(w io.Writer, entries any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(entries)
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"io"
+
+	"github.com/blck-snwmn/dependencylintgo/analyzer/config"
+	"github.com/blck-snwmn/dependencylintgo/analyzer/policy"
+)
+
+const deprecationsUsage = "usage: llinter deprecations [-config file] [-format table|json]"
+
+// scheduledDeprecation はJSONで出力する非推奨のimportの期限だ
+type scheduledDeprecation struct {
+	Import      string      `json:"import"`
+	Until       config.Date `json:"until"`
+	ReplaceWith string      `json:"replace_with,omitempty"`
+	DaysLeft    int         `json:"days_left"` // 期限までの日数。期限を過ぎていれば負
+	Expired     bool        `json:"expired"`
+}
+
+// runDeprecations は設定のdeprecateを期限の早い順に表示する
+func runDeprecations(args []string) int {
+	return deadlineList{
+		name:  "deprecations",
+		kind:  "deprecations",
+		usage: deprecationsUsage,
+		table: func(w io.Writer, pol *policy.Policy, today config.Date) error {
+			return printSchedule(w, pol.Schedule(today), today)
+		},
+		encode: func(w io.Writer, pol *policy.Policy, today config.Date) error {
+			return encodeSchedule(w, pol.Schedule(today), today)
+		},
+	}.run(args)
+}
+
+// printSchedule は非推奨のimportの期限を表形式で表示する
+func printSchedule(w io.Writer, schedule []policy.Deprecation, today config.Date) error {
+	rows := make([][]string, 0, len(schedule))
+	for _, d := range schedule {
+		rows = append(rows, []string{d.Config.Until.String(), scheduleStatus(d, today), d.Config.Import, d.Config.ReplaceWith})
+	}
+	return printTable(w, []string{"UNTIL", "STATUS", "IMPORT", "REPLACE WITH"}, rows)
+}
+
+// scheduleStatus は期限までの残りの日数か期限切れであることと、報告される重大度を表す
+func scheduleStatus(d policy.Deprecation, today config.Date) string {
+	if d.Expired {
+		return "expired (error)"
+	}
+	return deadlineStatus(d.Config.Until, false, today) + " (warning)"
+}
+
+// encodeSchedule は非推奨のimportの期限をJSONで出力する
+func encodeSchedule(w io.Writer, schedule []policy.Deprecation, today config.Date) error {
+	entries := make([]scheduledDeprecation, 0, len(schedule))
+	for _, d := range schedule {
+		entries = append(entries, scheduledDeprecation{
+			Import:      d.Config.Import,
+			Until:       d.Config.Until,
+			ReplaceWith: d.Config.ReplaceWith,
+			DaysLeft:    today.DaysUntil(d.Config.Until),
+			Expired:     d.Expired,
+		})
+	}
+	return encodeList(w, entries)
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/blck-snwmn/dependencylintgo/analyzer/config"
+	"github.com/blck-snwmn/dependencylintgo/analyzer/policy"
+)
+
+func TestPrintSchedule(t *testing.T) {
+	date := func(s string) config.Date {
+		d, err := config.ParseDate(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return d
+	}
+
+	pol := policy.New(&config.Config{
+		Deprecate: []config.Deprecation{
+			{Import: "github.com/old/log", Until: date("2026-12-31"), ReplaceWith: "log/slog"},
+			{Import: "github.com/old/http/**", Until: date("2026-01-31")},
+			{Import: "github.com/old/errors", Until: date("2026-10-19")},
+		},
+	})
+	today := date("2026-10-19")
+
+	var buf bytes.Buffer
+	if err := printSchedule(&buf, pol.Schedule(today), today); err != nil {
+		t.Fatal(err)
+	}
+	want := "UNTIL       STATUS                  IMPORT                  REPLACE WITH\n" +
+		"2026-01-31  expired (error)         github.com/old/http/**  \n" +
+		"2026-10-19  last day (warning)      github.com/old/errors   \n" +
+		"2026-12-31  73 days left (warning)  github.com/old/log      log/slog\n"
+	if buf.String() != want {
+		t.Errorf("printSchedule() =\n%s\nwant\n%s", buf.String(), want)
+	}
+
+	buf.Reset()
+	if err := encodeSchedule(&buf, pol.Schedule(today), today); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`"until": "2026-01-31"`, `"days_left": -261`, `"expired": true`, `"replace_with": "log/slog"`} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("Expected JSON to contain %s, got:\n%s", want, buf.String())
+		}
+	}
+}
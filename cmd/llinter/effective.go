@@ -0,0 +1,34 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/blck-snwmn/dependencylintgo/analyzer/config"
+	"gopkg.in/yaml.v3"
+)
+
+// printEffectiveConfig はディレクトリに適用される設定を階層的に探索して表示する
+func printEffectiveConfig(dir string) int {
+	cfg, root, paths, err := config.DiscoverFiles(dir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "llinter: %v\n", err)
+		return 1
+	}
+	if cfg == nil {
+		fmt.Fprintf(os.Stderr, "llinter: no %s found for %s\n", config.DefaultConfigName, dir)
+		return 1
+	}
+
+	fmt.Printf("# effective config for %s\n# paths are relative to %s\n", dir, root)
+	for _, path := range paths {
+		fmt.Printf("# merged from %s\n", path)
+	}
+	enc := yaml.NewEncoder(os.Stdout)
+	enc.SetIndent(2)
+	if err := enc.Encode(cfg); err != nil {
+		fmt.Fprintf(os.Stderr, "llinter: %v\n", err)
+		return 1
+	}
+	return 0
+}
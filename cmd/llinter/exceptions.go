@@ -0,0 +1,81 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/blck-snwmn/dependencylintgo/analyzer/config"
+	"github.com/blck-snwmn/dependencylintgo/analyzer/policy"
+)
+
+const exceptionsUsage = "usage: llinter exceptions list [-config file] [-format table|json]"
+
+// listedException はJSONで出力する例外だ
+type listedException struct {
+	Path     []string    `json:"path,omitempty"`
+	Packages []string    `json:"packages,omitempty"`
+	Import   string      `json:"import"`
+	Owner    string      `json:"owner"`
+	Ticket   string      `json:"ticket,omitempty"`
+	Expires  config.Date `json:"expires"`
+	DaysLeft int         `json:"days_left"` // 期限までの日数。期限を過ぎていれば負
+	Expired  bool        `json:"expired"`
+}
+
+// runExceptions は設定のexceptionsを扱うサブコマンドを実行する
+// 今はlistだけを提供する
+func runExceptions(args []string) int {
+	if len(args) == 0 || args[0] != "list" {
+		fmt.Fprintln(os.Stderr, exceptionsUsage)
+		return 2
+	}
+
+	return deadlineList{
+		name:  "exceptions list",
+		kind:  "exceptions",
+		usage: exceptionsUsage,
+		table: func(w io.Writer, pol *policy.Policy, today config.Date) error {
+			return printExceptions(w, pol.Exceptions(today), today)
+		},
+		encode: func(w io.Writer, pol *policy.Policy, today config.Date) error {
+			return encodeExceptions(w, pol.Exceptions(today), today)
+		},
+	}.run(args[1:])
+}
+
+// printExceptions は例外を表形式で表示する
+func printExceptions(w io.Writer, exceptions []policy.Exception, today config.Date) error {
+	rows := make([][]string, 0, len(exceptions))
+	for _, e := range exceptions {
+		c := e.Config
+		rows = append(rows, []string{c.Expires.String(), deadlineStatus(c.Expires, e.Expired, today), c.Owner, c.Ticket, exceptionScope(c), c.Import})
+	}
+	return printTable(w, []string{"EXPIRES", "STATUS", "OWNER", "TICKET", "SCOPE", "IMPORT"}, rows)
+}
+
+// exceptionScope は例外を適用するファイルとパッケージのパターンをカンマ区切りで表す
+func exceptionScope(e *config.Exception) string {
+	scope := append(append([]string(nil), e.Path...), e.Packages...)
+	return strings.Join(scope, ",")
+}
+
+// encodeExceptions は例外をJSONで出力する
+func encodeExceptions(w io.Writer, exceptions []policy.Exception, today config.Date) error {
+	entries := make([]listedException, 0, len(exceptions))
+	for _, e := range exceptions {
+		c := e.Config
+		entries = append(entries, listedException{
+			Path:     c.Path,
+			Packages: c.Packages,
+			Import:   c.Import,
+			Owner:    c.Owner,
+			Ticket:   c.Ticket,
+			Expires:  c.Expires,
+			DaysLeft: today.DaysUntil(c.Expires),
+			Expired:  e.Expired,
+		})
+	}
+	return encodeList(w, entries)
+}
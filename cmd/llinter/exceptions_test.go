@@ -0,0 +1,51 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/blck-snwmn/dependencylintgo/analyzer/config"
+	"github.com/blck-snwmn/dependencylintgo/analyzer/policy"
+)
+
+func TestPrintExceptions(t *testing.T) {
+	date := func(s string) config.Date {
+		d, err := config.ParseDate(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return d
+	}
+
+	pol := policy.New(&config.Config{
+		Exceptions: []config.Exception{
+			{Path: []string{"internal/legacy/**"}, Import: "github.com/old/log", Owner: "@team-a", Ticket: "APP-1", Expires: date("2026-12-31")},
+			{Packages: []string{"example.com/app/batch"}, Import: "os/exec", Owner: "@team-b", Expires: date("2026-01-31")},
+			{Path: []string{"cmd/**"}, Packages: []string{"example.com/app/cmd"}, Import: "unsafe", Owner: "@team-c", Expires: date("2026-10-20")},
+		},
+	})
+	today := date("2026-10-19")
+
+	var buf bytes.Buffer
+	if err := printExceptions(&buf, pol.Exceptions(today), today); err != nil {
+		t.Fatal(err)
+	}
+	want := "EXPIRES     STATUS        OWNER    TICKET  SCOPE                       IMPORT\n" +
+		"2026-01-31  expired       @team-b          example.com/app/batch       os/exec\n" +
+		"2026-10-20  1 day left    @team-c          cmd/**,example.com/app/cmd  unsafe\n" +
+		"2026-12-31  73 days left  @team-a  APP-1   internal/legacy/**          github.com/old/log\n"
+	if buf.String() != want {
+		t.Errorf("printExceptions() =\n%s\nwant\n%s", buf.String(), want)
+	}
+
+	buf.Reset()
+	if err := encodeExceptions(&buf, pol.Exceptions(today), today); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`"expires": "2026-01-31"`, `"days_left": -261`, `"expired": true`, `"owner": "@team-a"`, `"ticket": "APP-1"`} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("Expected JSON to contain %s, got:\n%s", want, buf.String())
+		}
+	}
+}
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"go/build"
+	"go/parser"
+	"go/token"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/blck-snwmn/dependencylintgo/analyzer/config"
+	"github.com/blck-snwmn/dependencylintgo/analyzer/policy"
+)
+
+const explainUsage = "usage: llinter explain [-config file] [-hierarchical] [-pkg path] -file <file> -import <path>"
+
+// runExplain はimportが許可・禁止される理由を表示する
+func runExplain(args []string) int {
+	fs := flag.NewFlagSet("explain", flag.ContinueOnError)
+	configFile := fs.String("config", config.DefaultConfigName, "configuration file path")
+	hierarchical := fs.Bool("hierarchical", false, "discover .llinter.yaml files from the module root down to the file's directory")
+	file := fs.String("file", "", "Go file that contains the import")
+	importPath := fs.String("import", "", "import path to check")
+	pkg := fs.String("pkg", "", "import path of the package that contains the file (default: derived from the file's directory)")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), explainUsage)
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if *file == "" || *importPath == "" || fs.NArg() != 0 {
+		fs.Usage()
+		return 2
+	}
+
+	e, err := explain(*configFile, *hierarchical, *file, *pkg, *importPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "llinter: %v\n", err)
+		return 1
+	}
+	printExplanation(os.Stdout, *file, e)
+	return 0
+}
+
+// explain はimportcheckと同じ方法で設定とファイルの情報を求めて判定する
+func explain(configFile string, hierarchical bool, file, pkg, importPath string) (policy.Explanation, error) {
+	filePath, err := filepath.Abs(file)
+	if err != nil {
+		return policy.Explanation{}, err
+	}
+
+	var (
+		cfg  *config.Config
+		root string
+	)
+	dir := filepath.Dir(filePath)
+	if hierarchical {
+		cfg, root, err = config.Discover(dir)
+	} else {
+		// goコマンドと同じく、GO111MODULE=offならGOPATHモードとしてimportパスで照合する
+		if os.Getenv("GO111MODULE") != "off" {
+			root, _, _ = config.FindModule(dir)
+		}
+		var configPath string
+		if configPath, err = config.ResolveConfigPathFor(configFile, dir); err == nil {
+			cfg, err = config.LoadConfig(configPath)
+		}
+	}
+	if err != nil {
+		return policy.Explanation{}, err
+	}
+
+	// 例外（exceptions）のpackagesと照合するため、指定がなければディレクトリからパッケージのimportパスを求める
+	if pkg == "" {
+		pkg = packagePath(dir)
+	}
+
+	path := config.MatchPath(filePath, gopathImportPath(dir), root)
+	info := config.File{Path: path, IsTest: strings.HasSuffix(path, "_test.go")}
+	// ファイルが存在すればビルド制約や生成コードかどうかも判定に使う
+	if f, err := parser.ParseFile(token.NewFileSet(), filePath, nil, parser.PackageClauseOnly|parser.ParseComments); err == nil {
+		info = config.NewFile(path, f)
+	} else if !os.IsNotExist(err) {
+		return policy.Explanation{}, err
+	}
+
+	return policy.New(cfg).Explain(info, pkg, importPath), nil
+}
+
+// printExplanation は判定の過程を表示する
+func printExplanation(w io.Writer, file string, e policy.Explanation) {
+	fmt.Fprintf(w, "file:   %s (matched as %s)\n", file, e.File.Path)
+	fmt.Fprintf(w, "import: %s\n\n", e.ImportPath)
+
+	if len(e.Rules) == 0 {
+		fmt.Fprintln(w, "no rules are configured")
+	}
+	for _, m := range e.Rules {
+		switch {
+		case m.PathPattern == "":
+			fmt.Fprintf(w, "rules[%d]: path %q does not match\n", m.Index, m.Rule.Path)
+		case !m.Applied:
+			fmt.Fprintf(w, "rules[%d]: path matches %q, skipped: %s\n", m.Index, m.PathPattern, m.Reason)
+		default:
+			fmt.Fprintf(w, "rules[%d]: path matches %q, applied\n", m.Index, m.PathPattern)
+			fmt.Fprintf(w, "  deny:  %s\n", quoteOrNone(e.Deny))
+			fmt.Fprintf(w, "  allow: %s\n", quoteOrNone(e.Allow))
+		}
+	}
+	for _, index := range e.Inherited {
+		fmt.Fprintf(w, "rules[%d]: inherited from a parent directory's configuration (deny only)\n", index)
+	}
+	fmt.Fprintln(w)
+
+	d := e.Decision
+	if x := d.Exception; x != nil {
+		status := "active"
+		if x.Expired {
+			status = "expired"
+		}
+		owner := "owner " + x.Config.Owner
+		if x.Config.Ticket != "" {
+			owner += ", ticket " + x.Config.Ticket
+		}
+		fmt.Fprintf(w, "exceptions[%d]: import %q matches, expires %s (%s), %s\n", x.Index, x.Config.Import, x.Config.Expires, owner, status)
+	}
+	if dep := d.Deprecation; dep != nil {
+		fmt.Fprintf(w, "deprecate[%d]: import %q matches, deprecated until %s (severity %s)\n", dep.Index, dep.Config.Import, dep.Config.Until, dep.Severity)
+	}
+	if d.Exception != nil || d.Deprecation != nil {
+		fmt.Fprintln(w)
+	}
+
+	switch {
+	case d.Denied && d.Exception != nil && d.Exception.Expired:
+		fmt.Fprintf(w, "verdict: denied by deny %q of rules[%d] (severity %s); exceptions[%d] expired on %s\n", d.Pattern, d.RuleIndex, d.Severity, d.Exception.Index, d.Exception.Config.Expires)
+	case d.Denied && d.Exception == nil:
+		fmt.Fprintf(w, "verdict: denied by deny %q of rules[%d] (severity %s)\n", d.Pattern, d.RuleIndex, d.Severity)
+	case !d.Allowed:
+		fmt.Fprintf(w, "verdict: denied by deprecate[%d] (deprecated until %s)\n", d.Deprecation.Index, d.Deprecation.Config.Until)
+	case d.Denied:
+		fmt.Fprintf(w, "verdict: allowed by exceptions[%d] until %s (overrides deny %q of rules[%d])\n", d.Exception.Index, d.Exception.Config.Expires, d.Pattern, d.RuleIndex)
+	case d.Rule == nil:
+		fmt.Fprintln(w, "verdict: allowed (no rule applies to this file)")
+	case e.Deny != "":
+		fmt.Fprintf(w, "verdict: allowed by allow %q of rules[%d] (overrides deny %q)\n", e.Allow, d.RuleIndex, e.Deny)
+	default:
+		fmt.Fprintf(w, "verdict: allowed (no deny pattern of rules[%d] matches)\n", d.RuleIndex)
+	}
+}
+
+func quoteOrNone(pattern string) string {
+	if pattern == "" {
+		return "(no match)"
+	}
+	return fmt.Sprintf("%q", pattern)
+}
+
+// packagePath はディレクトリのパッケージのimportパスを求める
+// モジュールに属さなければGOPATHモードとして求め、どちらでもなければ空文字列を返す
+func packagePath(dir string) string {
+	if os.Getenv("GO111MODULE") != "off" {
+		if moduleRoot, modulePath, err := config.FindModule(dir); err == nil {
+			if rel, err := filepath.Rel(moduleRoot, dir); err == nil {
+				return path.Join(modulePath, filepath.ToSlash(rel))
+			}
+		}
+	}
+	return gopathImportPath(dir)
+}
+
+// gopathImportPath はGOPATHモードでのディレクトリのimportパスを求める
+// GOPATHのsrc以下になければ空文字列を返す
+func gopathImportPath(dir string) string {
+	gopath := os.Getenv("GOPATH")
+	if gopath == "" {
+		gopath = build.Default.GOPATH
+	}
+	for _, entry := range filepath.SplitList(gopath) {
+		if rel, err := filepath.Rel(filepath.Join(entry, "src"), dir); err == nil && rel != "." && !strings.HasPrefix(rel, "..") {
+			return filepath.ToSlash(rel)
+		}
+	}
+	return ""
+}
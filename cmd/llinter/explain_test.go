@@ -0,0 +1,117 @@
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestExplain(t *testing.T) {
+	testdata, err := filepath.Abs(filepath.Join("..", "..", "testdata"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	configPath := filepath.Join(testdata, ".llinter.yaml")
+	// テストデータはanalyzerのテストと同じくGOPATHモードで照合する
+	t.Setenv("GO111MODULE", "off")
+	t.Setenv("GOPATH", testdata)
+
+	tests := []struct {
+		name       string
+		file       string
+		importPath string
+		want       []string
+	}{
+		{
+			name:       "denyで禁止",
+			file:       "../../testdata/src/example/forbidden.go",
+			importPath: "fmt",
+			want: []string{
+				"(matched as example/forbidden.go)",
+				`rules[0]: path matches "example/forbidden.go", applied`,
+				`verdict: denied by deny "fmt" of rules[0] (severity error)`,
+			},
+		},
+		{
+			name:       "テストファイルの条件で読み飛ばす",
+			file:       "../../testdata/src/testaware/server_test.go",
+			importPath: "net/http/httptest",
+			want: []string{
+				`rules[0]: path ["example/forbidden.go"] does not match`,
+				`rules[4]: path matches "testaware/*.go", skipped: test files are excluded (tests: exclude)`,
+				`rules[5]: path matches "testaware/*.go", applied`,
+				"verdict: allowed (no deny pattern of rules[5] matches)",
+			},
+		},
+		{
+			name:       "ディレクトリ単位のルール",
+			file:       "../../testdata/src/example/allowed.go",
+			importPath: "internal/x",
+			want: []string{
+				"verdict: denied by deny \"internal/**\" of rules[1] (severity error)",
+			},
+		},
+		{
+			name:       "期限内の例外で許可",
+			file:       "../../testdata/src/exceptions/active.go",
+			importPath: "os",
+			want: []string{
+				`exceptions[0]: import "os" matches, expires 2999-12-31 (owner @team-a, ticket APP-1), active`,
+				`verdict: allowed by exceptions[0] until 2999-12-31 (overrides deny "os" of rules[13])`,
+			},
+		},
+		{
+			name:       "期限切れの例外",
+			file:       "../../testdata/src/exceptions/other.go",
+			importPath: "strings",
+			want: []string{
+				`exceptions[1]: import "strings" matches, expires 2000-01-01 (owner @team-b, ticket APP-2), expired`,
+				`verdict: denied by deny "strings" of rules[13] (severity error); exceptions[1] expired on 2000-01-01`,
+			},
+		},
+		{
+			name:       "期限前の非推奨",
+			file:       "../../testdata/src/deprecated/app/app.go",
+			importPath: "deprecated/oldlog",
+			want: []string{
+				`deprecate[0]: import "deprecated/oldlog" matches, deprecated until 2999-12-31 (severity warning)`,
+				"verdict: allowed (no rule applies to this file)",
+			},
+		},
+		{
+			name:       "期限を過ぎた非推奨",
+			file:       "../../testdata/src/deprecated/app/app.go",
+			importPath: "deprecated/oldhttp/client",
+			want: []string{
+				`deprecate[1]: import "deprecated/oldhttp/**" matches, deprecated until 2000-01-01 (severity error)`,
+				"verdict: denied by deprecate[1] (deprecated until 2000-01-01)",
+			},
+		},
+		{
+			name:       "存在しないファイル",
+			file:       "../../testdata/src/nowhere/main.go",
+			importPath: "fmt",
+			want: []string{
+				"verdict: allowed (no rule applies to this file)",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, err := explain(configPath, false, tt.file, "", tt.importPath)
+			if err != nil {
+				t.Fatalf("explain() error = %v", err)
+			}
+
+			var buf bytes.Buffer
+			printExplanation(&buf, tt.file, e)
+			for _, want := range tt.want {
+				if !strings.Contains(buf.String(), want) {
+					t.Errorf("Expected output to contain %q, got:\n%s", want, buf.String())
+				}
+			}
+		})
+	}
+}
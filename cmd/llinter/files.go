@@ -0,0 +1,82 @@
+package main
+
+import (
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/blck-snwmn/dependencylintgo/analyzer/config"
+	"golang.org/x/tools/go/packages"
+)
+
+// sourceFile はimportcheckと同じ方法でルールと照合するファイルの情報だ
+type sourceFile struct {
+	pkg     *packages.Package
+	file    config.File
+	imports []string
+}
+
+// loadSourcePackages はsourceFilesでルールと照合するパッケージを読み込む
+// ファイルはモジュールルートからの相対パスで照合するので、モジュールの情報も読み込む
+func loadSourcePackages(patterns []string, tests bool) ([]*packages.Package, error) {
+	return packages.Load(&packages.Config{
+		Mode:  packages.NeedName | packages.NeedFiles | packages.NeedModule,
+		Tests: tests,
+	}, patterns...)
+}
+
+// sourceFiles はパッケージのファイルを読み込む
+// テスト用に生成されるmainパッケージは除き、テストを含むパッケージは本体のファイルも含むので同じファイルは一度だけ返す
+func sourceFiles(pkgs []*packages.Package) ([]sourceFile, error) {
+	var files []sourceFile
+	fset := token.NewFileSet()
+	seen := make(map[string]bool)
+
+	for _, pkg := range pkgs {
+		if strings.HasSuffix(pkg.PkgPath, ".test") {
+			continue
+		}
+		// ファイルはそれを含むモジュールのルートからの相対パスで照合する（GOPATHモードではimportパスを使う）
+		// 解析中のモジュールに属さない依存パッケージは照合しない
+		root := ""
+		if pkg.Module != nil {
+			if !pkg.Module.Main {
+				continue
+			}
+			root = pkg.Module.Dir
+		}
+		for _, filename := range pkg.GoFiles {
+			if seen[filename] {
+				continue
+			}
+			seen[filename] = true
+
+			f, err := parser.ParseFile(fset, filename, nil, parser.ImportsOnly|parser.ParseComments)
+			if err != nil {
+				return nil, err
+			}
+
+			imports := make([]string, 0, len(f.Imports))
+			for _, spec := range f.Imports {
+				importPath, err := strconv.Unquote(spec.Path.Value)
+				if err != nil {
+					continue
+				}
+				imports = append(imports, importPath)
+			}
+
+			abs, err := filepath.Abs(filename)
+			if err != nil {
+				return nil, err
+			}
+			files = append(files, sourceFile{
+				pkg:     pkg,
+				file:    config.NewFile(config.MatchPath(abs, pkg.PkgPath, root), f),
+				imports: imports,
+			})
+		}
+	}
+	return files, nil
+}
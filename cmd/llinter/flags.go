@@ -0,0 +1,86 @@
+package main
+
+import (
+	"strings"
+)
+
+// sharedFlag はフラグの値を同名のフラグを持つすべてのanalyzerに設定する
+// analyzerのフラグは「analyzer名.フラグ名」で公開されるので、共通のフラグとして公開するために使う
+type sharedFlag struct {
+	name   string
+	isBool bool
+}
+
+func (f sharedFlag) String() string { return "" }
+
+func (f sharedFlag) Set(value string) error {
+	for _, a := range analyzers {
+		if a.Flags.Lookup(f.name) == nil {
+			continue
+		}
+		if err := a.Flags.Set(f.name, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f sharedFlag) IsBoolFlag() bool { return f.isBool }
+
+// extractFlag はコマンドライン引数から指定した名前のフラグを取り除き、その値を返す
+// analyzerのフラグを解析する前に処理する必要があるフラグに使う
+func extractFlag(args []string, flagName string) ([]string, []string) {
+	var rest, values []string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			rest = append(rest, args[i:]...)
+			break
+		}
+
+		name := strings.TrimLeft(arg, "-")
+		if name == arg {
+			rest = append(rest, arg)
+			continue
+		}
+
+		switch {
+		case name == flagName && i+1 < len(args):
+			values = append(values, args[i+1])
+			i++
+		case strings.HasPrefix(name, flagName+"="):
+			values = append(values, strings.TrimPrefix(name, flagName+"="))
+		default:
+			rest = append(rest, arg)
+		}
+	}
+	return rest, values
+}
+
+// extractBoolFlag はコマンドライン引数から指定した名前の真偽値フラグを取り除き、有効になっていたか返す
+func extractBoolFlag(args []string, flagName string) ([]string, bool) {
+	var rest []string
+	enabled := false
+	for i, arg := range args {
+		if arg == "--" {
+			rest = append(rest, args[i:]...)
+			break
+		}
+
+		name := strings.TrimLeft(arg, "-")
+		if name == arg {
+			rest = append(rest, arg)
+			continue
+		}
+
+		switch name {
+		case flagName, flagName + "=true":
+			enabled = true
+		case flagName + "=false":
+			enabled = false
+		default:
+			rest = append(rest, arg)
+		}
+	}
+	return rest, enabled
+}
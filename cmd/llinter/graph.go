@@ -0,0 +1,292 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/blck-snwmn/dependencylintgo/analyzer/config"
+	"github.com/blck-snwmn/dependencylintgo/analyzer/policy"
+	"golang.org/x/tools/go/packages"
+)
+
+const graphUsage = "usage: llinter graph [-config file] [-format dot|mermaid|json] [-level package|group] [-external] [packages]"
+
+// depGraph はimportの依存グラフだ
+type depGraph struct {
+	Level string      `json:"level"`
+	Nodes []string    `json:"nodes"`
+	Edges []graphEdge `json:"edges"`
+}
+
+// graphEdge は依存グラフの辺だ
+type graphEdge struct {
+	From       string           `json:"from"`
+	To         string           `json:"to"`
+	Severity   string           `json:"severity,omitempty"` // 違反がある場合の最も重い重大度
+	Violations []graphViolation `json:"violations,omitempty"`
+}
+
+// graphViolation はルールに違反するimportだ
+type graphViolation struct {
+	File     string `json:"file"`
+	Import   string `json:"import"`
+	Rule     int    `json:"rule"` // 違反したルールの位置。期限を過ぎた非推奨のimportだけなら-1
+	Pattern  string `json:"pattern"`
+	Severity string `json:"severity"`
+	Reason   string `json:"reason"`            // 違反の理由（deny|expired_exception|deprecated）
+	Expired  string `json:"expired,omitempty"` // 期限切れの例外や非推奨のimportの期限
+}
+
+// 違反の理由
+const (
+	reasonDeny             = "deny"              // ルールのdenyで禁止されている
+	reasonExpiredException = "expired_exception" // ルールのdenyで禁止され、例外の期限が切れている
+	reasonDeprecated       = "deprecated"        // 非推奨のimportの期限を過ぎた
+)
+
+// newGraphViolation はimportの判定から違反を作る
+// 期限内の例外で許可されたimportは違反にしない
+func newGraphViolation(file config.File, importPath string, d policy.Decision) (graphViolation, bool) {
+	if d.Allowed {
+		return graphViolation{}, false
+	}
+
+	v := graphViolation{
+		File:     file.Path,
+		Import:   importPath,
+		Rule:     d.RuleIndex,
+		Pattern:  d.Pattern,
+		Severity: d.Severity,
+		Reason:   reasonDeny,
+	}
+	switch {
+	case d.Denied && d.Exception != nil:
+		v.Reason = reasonExpiredException
+		v.Expired = d.Exception.Config.Expires.String()
+	case !d.Denied:
+		v.Reason = reasonDeprecated
+		v.Severity = d.Deprecation.Severity
+		v.Expired = d.Deprecation.Config.Until.String()
+	}
+	return v, true
+}
+
+// graphRenderers は出力形式ごとの描画関数だ
+var graphRenderers = map[string]func(w io.Writer, g *depGraph) error{
+	"dot":     renderDOT,
+	"mermaid": renderMermaid,
+	"json":    renderGraphJSON,
+}
+
+// runGraph はパッケージまたはグループの依存グラフを出力する
+func runGraph(args []string) int {
+	fs := flag.NewFlagSet("graph", flag.ContinueOnError)
+	configFile := fs.String("config", config.DefaultConfigName, "configuration file path")
+	format := fs.String("format", "dot", "output format (dot, mermaid or json)")
+	level := fs.String("level", "package", "graph level (package or group)")
+	external := fs.Bool("external", false, "include imports of packages outside the given patterns")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), graphUsage)
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	render, ok := graphRenderers[*format]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "llinter: unknown graph format %q\n", *format)
+		return 2
+	}
+	if *level != "package" && *level != "group" {
+		fmt.Fprintf(os.Stderr, "llinter: unknown graph level %q\n", *level)
+		return 2
+	}
+	patterns := fs.Args()
+	if len(patterns) == 0 {
+		patterns = []string{"./..."}
+	}
+
+	// 設定ファイルがなくてもグラフは描ける
+	cfg, err := config.LoadConfig(*configFile)
+	if err != nil && !config.IsNotFound(err) {
+		fmt.Fprintf(os.Stderr, "llinter: %v\n", err)
+		return 1
+	}
+	if *level == "group" && (cfg == nil || len(cfg.Groups) == 0) {
+		fmt.Fprintln(os.Stderr, "llinter: -level group requires groups in the configuration")
+		return 1
+	}
+
+	pkgs, err := loadSourcePackages(patterns, false)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "llinter: %v\n", err)
+		return 1
+	}
+	if packages.PrintErrors(pkgs) > 0 {
+		return 1
+	}
+
+	g, err := buildGraph(cfg, pkgs, *level == "group", *external)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "llinter: %v\n", err)
+		return 1
+	}
+	if err := render(os.Stdout, g); err != nil {
+		fmt.Fprintf(os.Stderr, "llinter: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// buildGraph はパッケージのimportから依存グラフを作り、ルールに違反する辺に印を付ける
+// groupsがtrueなら設定のグループ単位にまとめ、どのグループにも属さないパッケージは除く
+// externalがfalseなら読み込んだパッケージ以外へのimportは除く
+func buildGraph(cfg *config.Config, pkgs []*packages.Package, groups, external bool) (*depGraph, error) {
+	files, err := sourceFiles(pkgs)
+	if err != nil {
+		return nil, err
+	}
+
+	loaded := make(map[string]bool)
+	for _, pkg := range pkgs {
+		loaded[pkg.PkgPath] = true
+	}
+
+	node := func(pkgPath string) (string, bool) {
+		if !groups {
+			return pkgPath, true
+		}
+		if group := config.FindGroup(cfg, pkgPath); group != nil {
+			return group.Name, true
+		}
+		return "", false
+	}
+
+	g := &depGraph{Level: "package"}
+	if groups {
+		g.Level = "group"
+	}
+	nodes := make(map[string]bool)
+	edges := make(map[[2]string]*graphEdge)
+	pol := policy.New(cfg)
+
+	for _, f := range files {
+		from, ok := node(f.pkg.PkgPath)
+		if !ok {
+			continue
+		}
+		nodes[from] = true
+
+		for _, importPath := range f.imports {
+			if !external && !loaded[importPath] {
+				continue
+			}
+			to, ok := node(importPath)
+			if !ok || to == from {
+				continue
+			}
+			nodes[to] = true
+
+			key := [2]string{from, to}
+			e := edges[key]
+			if e == nil {
+				e = &graphEdge{From: from, To: to}
+				edges[key] = e
+			}
+
+			// importcheckと同じく、例外と非推奨のimportも含めて判定する
+			if v, ok := newGraphViolation(f.file, importPath, pol.Check(f.file, f.pkg.PkgPath, importPath)); ok {
+				e.Violations = append(e.Violations, v)
+				if e.Severity != config.SeverityError {
+					e.Severity = v.Severity
+				}
+			}
+		}
+	}
+
+	for n := range nodes {
+		g.Nodes = append(g.Nodes, n)
+	}
+	sort.Strings(g.Nodes)
+	for _, e := range edges {
+		g.Edges = append(g.Edges, *e)
+	}
+	sort.Slice(g.Edges, func(i, j int) bool {
+		if g.Edges[i].From != g.Edges[j].From {
+			return g.Edges[i].From < g.Edges[j].From
+		}
+		return g.Edges[i].To < g.Edges[j].To
+	})
+	return g, nil
+}
+
+// edgeColor は違反の重大度に応じた辺の色を返す
+func edgeColor(severity string) string {
+	switch severity {
+	case config.SeverityError:
+		return "red"
+	case config.SeverityWarning:
+		return "orange"
+	}
+	return ""
+}
+
+// renderDOT はGraphvizのDOT形式で出力する
+func renderDOT(w io.Writer, g *depGraph) error {
+	var b strings.Builder
+	b.WriteString("digraph imports {\n")
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [shape=box];\n")
+	for _, n := range g.Nodes {
+		fmt.Fprintf(&b, "  %q;\n", n)
+	}
+	for _, e := range g.Edges {
+		if color := edgeColor(e.Severity); color != "" {
+			fmt.Fprintf(&b, "  %q -> %q [color=%s];\n", e.From, e.To, color)
+			continue
+		}
+		fmt.Fprintf(&b, "  %q -> %q;\n", e.From, e.To)
+	}
+	b.WriteString("}\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// renderMermaid はMermaidのflowchart形式で出力する
+// Markdownに埋め込めるように、ノードのIDは連番にしてimportパスはラベルにする
+func renderMermaid(w io.Writer, g *depGraph) error {
+	var b strings.Builder
+	b.WriteString("graph LR\n")
+	ids := make(map[string]string, len(g.Nodes))
+	for i, n := range g.Nodes {
+		ids[n] = fmt.Sprintf("n%d", i)
+		fmt.Fprintf(&b, "  %s[\"%s\"]\n", ids[n], strings.ReplaceAll(n, `"`, "#quot;"))
+	}
+
+	styles := make(map[string][]string)
+	for i, e := range g.Edges {
+		fmt.Fprintf(&b, "  %s --> %s\n", ids[e.From], ids[e.To])
+		if color := edgeColor(e.Severity); color != "" {
+			styles[color] = append(styles[color], fmt.Sprint(i))
+		}
+	}
+	for _, color := range []string{"red", "orange"} {
+		if len(styles[color]) > 0 {
+			fmt.Fprintf(&b, "  linkStyle %s stroke:%s,stroke-width:2px\n", strings.Join(styles[color], ","), color)
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// renderGraphJSON はJSON形式で出力する
+func renderGraphJSON(w io.Writer, g *depGraph) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(g)
+}
@@ -0,0 +1,199 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/blck-snwmn/dependencylintgo/analyzer/config"
+	"golang.org/x/tools/go/packages"
+)
+
+// loadTestModule は一時ディレクトリにモジュールを作ってパッケージを読み込む
+func loadTestModule(t *testing.T, files map[string]string) (string, []*packages.Package) {
+	t.Helper()
+	dir := writeTestModule(t, files)
+
+	pkgs, err := packages.Load(&packages.Config{
+		Mode:  packages.NeedName | packages.NeedFiles | packages.NeedImports | packages.NeedModule,
+		Tests: true,
+		Dir:   dir,
+	}, "./...")
+	if err != nil {
+		t.Fatalf("Failed to load packages: %v", err)
+	}
+	return dir, pkgs
+}
+
+// loadSourceTestModule は一時ディレクトリにモジュールを作り、graphや-report-unused-rulesと同じ方法でパッケージを読み込む
+func loadSourceTestModule(t *testing.T, files map[string]string, tests bool) []*packages.Package {
+	t.Helper()
+	t.Chdir(writeTestModule(t, files))
+
+	pkgs, err := loadSourcePackages([]string{"./..."}, tests)
+	if err != nil {
+		t.Fatalf("Failed to load packages: %v", err)
+	}
+	if packages.PrintErrors(pkgs) > 0 {
+		t.Fatal("Failed to load packages")
+	}
+	return pkgs
+}
+
+// writeTestModule は一時ディレクトリにファイルを書き出し、そのディレクトリを返す
+func writeTestModule(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestBuildGraph(t *testing.T) {
+	pkgs := loadSourceTestModule(t, map[string]string{
+		"go.mod":     "module example.com/app\n\ngo 1.24\n",
+		"api/api.go": "package api\n\nimport (\n\t_ \"example.com/app/db\"\n\t_ \"example.com/app/model\"\n\t_ \"fmt\"\n)\n",
+		"db/db.go":   "package db\n\nimport _ \"example.com/app/model\"\n",
+		"model/m.go": "package model\n",
+	}, false)
+	cfg := &config.Config{
+		Rules: []config.Rule{
+			{Path: []string{"api/**"}, Deny: []string{"example.com/app/db"}},
+		},
+		Groups: []config.Group{
+			{Name: "web", Packages: []string{"example.com/app/api"}},
+			{Name: "storage", Packages: []string{"example.com/app/db", "example.com/app/model"}},
+		},
+	}
+
+	t.Run("パッケージ単位", func(t *testing.T) {
+		g, err := buildGraph(cfg, pkgs, false, false)
+		if err != nil {
+			t.Fatalf("buildGraph() error = %v", err)
+		}
+
+		var dot bytes.Buffer
+		if err := renderDOT(&dot, g); err != nil {
+			t.Fatal(err)
+		}
+		for _, want := range []string{
+			`"example.com/app/api" -> "example.com/app/db" [color=red];`,
+			`"example.com/app/api" -> "example.com/app/model";`,
+			`"example.com/app/db" -> "example.com/app/model";`,
+		} {
+			if !strings.Contains(dot.String(), want) {
+				t.Errorf("Expected DOT to contain %q, got:\n%s", want, dot.String())
+			}
+		}
+		if strings.Contains(dot.String(), `"fmt"`) {
+			t.Errorf("Expected external packages to be excluded, got:\n%s", dot.String())
+		}
+
+		var mermaid bytes.Buffer
+		if err := renderMermaid(&mermaid, g); err != nil {
+			t.Fatal(err)
+		}
+		for _, want := range []string{
+			`n0["example.com/app/api"]`,
+			"n0 --> n1",
+			"linkStyle 0 stroke:red,stroke-width:2px",
+		} {
+			if !strings.Contains(mermaid.String(), want) {
+				t.Errorf("Expected Mermaid to contain %q, got:\n%s", want, mermaid.String())
+			}
+		}
+	})
+
+	t.Run("グループ単位", func(t *testing.T) {
+		g, err := buildGraph(cfg, pkgs, true, true)
+		if err != nil {
+			t.Fatalf("buildGraph() error = %v", err)
+		}
+
+		if len(g.Edges) != 1 {
+			t.Fatalf("Expected 1 edge, got %+v", g.Edges)
+		}
+		e := g.Edges[0]
+		if e.From != "web" || e.To != "storage" || e.Severity != config.SeverityError || len(e.Violations) != 1 {
+			t.Errorf("Expected violating edge web -> storage, got %+v", e)
+		}
+
+		var out bytes.Buffer
+		if err := renderGraphJSON(&out, g); err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(out.String(), `"import": "example.com/app/db"`) {
+			t.Errorf("Expected JSON to contain the violation, got:\n%s", out.String())
+		}
+	})
+}
+
+func TestBuildGraphExceptions(t *testing.T) {
+	pkgs := loadSourceTestModule(t, map[string]string{
+		"go.mod":           "module example.com/app\n\ngo 1.22\n",
+		"web/web.go":       "package web\n\nimport _ \"example.com/app/db\"\n",
+		"api/api.go":       "package api\n\nimport _ \"example.com/app/db\"\n",
+		"batch/batch.go":   "package batch\n\nimport _ \"example.com/app/legacy\"\n",
+		"db/db.go":         "package db\n",
+		"legacy/legacy.go": "package legacy\n",
+	}, false)
+	date := func(s string) config.Date {
+		d, err := config.ParseDate(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return d
+	}
+	cfg := &config.Config{
+		Rules: []config.Rule{
+			{Path: []string{"api/**", "web/**"}, Deny: []string{"example.com/app/db"}},
+		},
+		Deprecate: []config.Deprecation{
+			{Import: "example.com/app/legacy", Until: date("2000-01-01")},
+		},
+		Exceptions: []config.Exception{
+			{Path: []string{"web/web.go"}, Import: "example.com/app/db", Owner: "@team-a", Expires: date("2999-12-31")},
+			{Packages: []string{"example.com/app/api"}, Import: "example.com/app/db", Owner: "@team-b", Expires: date("2000-01-01")},
+		},
+	}
+
+	g, err := buildGraph(cfg, pkgs, false, false)
+	if err != nil {
+		t.Fatalf("buildGraph() error = %v", err)
+	}
+
+	edges := make(map[string]graphEdge)
+	for _, e := range g.Edges {
+		edges[e.From+" -> "+e.To] = e
+	}
+
+	// 期限内の例外で許可されたimportは違反にしない
+	if e := edges["example.com/app/web -> example.com/app/db"]; e.Severity != "" || len(e.Violations) != 0 {
+		t.Errorf("Expected edge allowed by the active exception, got %+v", e)
+	}
+
+	e := edges["example.com/app/api -> example.com/app/db"]
+	if e.Severity != config.SeverityError || len(e.Violations) != 1 {
+		t.Fatalf("Expected edge denied with the expired exception, got %+v", e)
+	}
+	if v := e.Violations[0]; v.Reason != reasonExpiredException || v.Expired != "2000-01-01" || v.Rule != 0 {
+		t.Errorf("Unexpected violation: %+v", v)
+	}
+
+	e = edges["example.com/app/batch -> example.com/app/legacy"]
+	if e.Severity != config.SeverityError || len(e.Violations) != 1 {
+		t.Fatalf("Expected edge denied by the expired deprecation, got %+v", e)
+	}
+	if v := e.Violations[0]; v.Reason != reasonDeprecated || v.Expired != "2000-01-01" || v.Rule != -1 {
+		t.Errorf("Unexpected violation: %+v", v)
+	}
+}
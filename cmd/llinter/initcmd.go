@@ -0,0 +1,147 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/blck-snwmn/dependencylintgo/analyzer/config"
+	"golang.org/x/tools/go/packages"
+	"gopkg.in/yaml.v3"
+)
+
+const initUsage = "usage: llinter init [-o file] [-force] [packages]"
+
+// runInit は現在の依存関係から設定ファイルのひな形を作る
+func runInit(args []string) int {
+	fs := flag.NewFlagSet("init", flag.ContinueOnError)
+	output := fs.String("o", "", "write the config to `file` instead of stdout")
+	force := fs.Bool("force", false, "overwrite the output file if it exists")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), initUsage)
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	patterns := fs.Args()
+	if len(patterns) == 0 {
+		patterns = []string{"./..."}
+	}
+
+	root, _, err := config.FindModule(".")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "llinter: %v\n", err)
+		return 1
+	}
+
+	pkgs, err := packages.Load(&packages.Config{
+		Mode:  packages.NeedName | packages.NeedFiles | packages.NeedImports,
+		Tests: true,
+	}, patterns...)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "llinter: %v\n", err)
+		return 1
+	}
+	if packages.PrintErrors(pkgs) > 0 {
+		return 1
+	}
+
+	data, err := encodeInitConfig(generateConfig(pkgs, root))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "llinter: %v\n", err)
+		return 1
+	}
+
+	if *output == "" {
+		os.Stdout.Write(data)
+		return 0
+	}
+	if !*force {
+		if _, err := os.Stat(*output); err == nil {
+			fmt.Fprintf(os.Stderr, "llinter: %s already exists (use -force to overwrite)\n", *output)
+			return 1
+		} else if !errors.Is(err, os.ErrNotExist) {
+			fmt.Fprintf(os.Stderr, "llinter: %v\n", err)
+			return 1
+		}
+	}
+	if err := os.WriteFile(*output, data, 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "llinter: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// generateConfig はパッケージをモジュールルート直下のディレクトリごとにまとめ、
+// 各ディレクトリで現在importしているパッケージだけを許可するルールを作る
+func generateConfig(pkgs []*packages.Package, root string) *config.Config {
+	imports := make(map[string]map[string]bool)
+	for _, pkg := range pkgs {
+		// テスト用に生成されるmainパッケージは対象外
+		if strings.HasSuffix(pkg.PkgPath, ".test") || len(pkg.GoFiles) == 0 {
+			continue
+		}
+		rel, err := filepath.Rel(root, filepath.Dir(pkg.GoFiles[0]))
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			continue
+		}
+		dir := strings.SplitN(filepath.ToSlash(rel), "/", 2)[0]
+
+		if imports[dir] == nil {
+			imports[dir] = make(map[string]bool)
+		}
+		for importPath := range pkg.Imports {
+			// 外部テストパッケージからテスト対象へのimportも含まれる
+			imports[dir][importPath] = true
+		}
+	}
+
+	dirs := make([]string, 0, len(imports))
+	for dir := range imports {
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+
+	cfg := &config.Config{Version: config.CurrentVersion}
+	for _, dir := range dirs {
+		allow := make([]string, 0, len(imports[dir]))
+		for importPath := range imports[dir] {
+			allow = append(allow, importPath)
+		}
+		sort.Strings(allow)
+
+		path := dir + "/**"
+		if dir == "." {
+			// モジュールルート直下のファイル
+			path = "*.go"
+		}
+		cfg.Rules = append(cfg.Rules, config.Rule{
+			Path:  []string{path},
+			Deny:  []string{"**"},
+			Allow: allow,
+		})
+	}
+	return cfg
+}
+
+// encodeInitConfig は生成した設定をYAMLにする
+func encodeInitConfig(cfg *config.Config) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString("# Generated by llinter init from the current import graph.\n")
+	buf.WriteString("# Each rule allows only the imports that the directory uses today; remove entries to tighten it.\n")
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(cfg); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
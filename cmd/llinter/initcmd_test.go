@@ -0,0 +1,75 @@
+package main
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/blck-snwmn/dependencylintgo/analyzer/config"
+	"golang.org/x/tools/go/packages"
+	"gopkg.in/yaml.v3"
+)
+
+func TestGenerateConfig(t *testing.T) {
+	root := filepath.FromSlash("/repo")
+	file := func(path string) []string {
+		return []string{filepath.Join(root, filepath.FromSlash(path))}
+	}
+	imports := func(paths ...string) map[string]*packages.Package {
+		m := make(map[string]*packages.Package)
+		for _, path := range paths {
+			m[path] = &packages.Package{PkgPath: path}
+		}
+		return m
+	}
+
+	pkgs := []*packages.Package{
+		{PkgPath: "example.com/app", GoFiles: file("main.go"), Imports: imports("example.com/app/internal/api")},
+		{PkgPath: "example.com/app/internal/api", GoFiles: file("internal/api/api.go"), Imports: imports("net/http", "example.com/app/internal/store")},
+		{PkgPath: "example.com/app/internal/store", GoFiles: file("internal/store/store.go"), Imports: imports("database/sql")},
+		{PkgPath: "example.com/app/internal/store_test", GoFiles: file("internal/store/store_test.go"), Imports: imports("testing", "example.com/app/internal/store")},
+		{PkgPath: "example.com/app/internal/store.test", GoFiles: []string{"/tmp/go-build/testmain.go"}, Imports: imports("os")},
+		{PkgPath: "example.com/other", GoFiles: []string{filepath.FromSlash("/other/other.go")}, Imports: imports("fmt")},
+	}
+
+	got := generateConfig(pkgs, root)
+	want := &config.Config{
+		Version: config.CurrentVersion,
+		Rules: []config.Rule{
+			{
+				Path:  []string{"*.go"},
+				Deny:  []string{"**"},
+				Allow: []string{"example.com/app/internal/api"},
+			},
+			{
+				Path:  []string{"internal/**"},
+				Deny:  []string{"**"},
+				Allow: []string{"database/sql", "example.com/app/internal/store", "net/http", "testing"},
+			},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("generateConfig() = %+v, want %+v", got, want)
+	}
+
+	// 生成した設定は読み込める形式で、現在のimportを許可する
+	data, err := encodeInitConfig(got)
+	if err != nil {
+		t.Fatalf("encodeInitConfig() error = %v", err)
+	}
+	if !strings.HasPrefix(string(data), "# Generated by llinter init") {
+		t.Errorf("Expected header comment, got:\n%s", data)
+	}
+	var decoded config.Config
+	if err := yaml.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Failed to decode generated config: %v", err)
+	}
+	rule := config.FindMatchingRule(&decoded, "internal/api/api.go")
+	if rule == nil {
+		t.Fatal("Expected a rule for internal/api/api.go")
+	}
+	if !config.IsImportPathMatched("net/http", rule.Allow) || !config.IsImportPathMatched("os", rule.Deny) {
+		t.Errorf("Expected net/http to be allowed and os to be denied, got %+v", rule)
+	}
+}
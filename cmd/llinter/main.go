@@ -0,0 +1,82 @@
+// llinter はimport文の依存関係をチェックするLinterのコマンドだ
+package main
+
+import (
+	"flag"
+	"os"
+	"strings"
+
+	"github.com/blck-snwmn/dependencylintgo/analyzer/groupcycle"
+	"github.com/blck-snwmn/dependencylintgo/analyzer/importcheck"
+	"github.com/blck-snwmn/dependencylintgo/analyzer/licensecheck"
+	"github.com/blck-snwmn/dependencylintgo/analyzer/modcheck"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/multichecker"
+)
+
+// analyzers はllinterが実行するanalyzerの一覧だ
+var analyzers = []*analysis.Analyzer{
+	importcheck.Analyzer,
+	groupcycle.Analyzer,
+	modcheck.Analyzer,
+	licensecheck.Analyzer,
+}
+
+// subcommands はanalyzerの実行以外のサブコマンドだ
+var subcommands = map[string]func(args []string) int{
+	"schema":       runSchema,
+	"config":       runConfig,
+	"explain":      runExplain,
+	"init":         runInit,
+	"graph":        runGraph,
+	"metrics":      runMetrics,
+	"deprecations": runDeprecations,
+	"exceptions":   runExceptions,
+}
+
+func main() {
+	if len(os.Args) > 1 {
+		if cmd, ok := subcommands[os.Args[1]]; ok {
+			os.Exit(cmd(os.Args[2:]))
+		}
+	}
+
+	args := os.Args[1:]
+
+	// go vet -vettoolから実行された場合はmulticheckerに任せる
+	if isVetTool(args) {
+		flag.Var(sharedFlag{name: "config"}, "config", "configuration file path (default .llinter.yaml)")
+		flag.Var(sharedFlag{name: "verbose", isBool: true}, "verbose", "report which configuration file is used")
+		flag.Var(sharedFlag{name: "hierarchical", isBool: true}, "hierarchical", "discover .llinter.yaml files from the module root down to each package directory")
+		multichecker.Main(analyzers...)
+	}
+
+	// 階層的に探索した設定を表示して終了する
+	args, dirs := extractFlag(args, "print-effective-config")
+	if len(dirs) > 0 {
+		os.Exit(printEffectiveConfig(dirs[len(dirs)-1]))
+	}
+
+	// 使われていないルールを報告する
+	if args, ok := extractBoolFlag(args, "report-unused-rules"); ok {
+		os.Exit(reportUnusedRules(args))
+	}
+
+	// -targetが指定されたらターゲットごとに自身を実行し直す
+	if args, targets := extractFlag(args, "target"); len(targets) > 0 {
+		os.Exit(runTargets(targets, args))
+	}
+
+	os.Exit(runChecker(args, os.Environ(), os.Stdout, os.Stderr))
+}
+
+// isVetTool はgo vet -vettoolから実行されたか確認する
+// go vetはフラグの問い合わせ（-flags、-V=full）と、パッケージごとの設定ファイル（*.cfg）の解析で実行する
+func isVetTool(args []string) bool {
+	for _, arg := range args {
+		if arg == "-flags" || arg == "-V=full" {
+			return true
+		}
+	}
+	return len(args) > 0 && strings.HasSuffix(args[len(args)-1], ".cfg")
+}
@@ -0,0 +1,277 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/blck-snwmn/dependencylintgo/analyzer/config"
+	"github.com/blck-snwmn/dependencylintgo/analyzer/policy"
+	"golang.org/x/tools/go/packages"
+)
+
+const metricsUsage = "usage: llinter metrics [-config file] [-format table|json] [-level package|group] [packages]"
+
+// unitMetrics はパッケージまたはグループの結合度だ
+type unitMetrics struct {
+	Name        string  `json:"name"`
+	Afferent    int     `json:"afferent"`    // Ca: このパッケージに依存している、解析したパッケージの数
+	Efferent    int     `json:"efferent"`    // Ce: このパッケージが依存している、標準ライブラリ以外のパッケージの数
+	Instability float64 `json:"instability"` // Ce/(Ca+Ce)。どちらも0なら0
+	Std         int     `json:"std"`         // importしている標準ライブラリのパッケージ数
+	Internal    int     `json:"internal"`    // importしている同じモジュールのパッケージ数
+	ThirdParty  int     `json:"third_party"` // importしている外部モジュールのパッケージ数
+
+	pos string // 閾値を超えたときに報告する位置
+}
+
+// metricsViolation は閾値を超えた結合度だ
+type metricsViolation struct {
+	Name     string `json:"name"`
+	Position string `json:"position,omitempty"`
+	Message  string `json:"message"`
+}
+
+// runMetrics はパッケージまたはグループごとの結合度を表示し、設定の閾値を超えたものを報告する
+// 閾値を超えたものがあれば1を返す
+func runMetrics(args []string) int {
+	fs := flag.NewFlagSet("metrics", flag.ContinueOnError)
+	configFile := fs.String("config", config.DefaultConfigName, "configuration file path")
+	format := fs.String("format", "table", "output format (table or json)")
+	level := fs.String("level", "package", "metrics level (package or group)")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), metricsUsage)
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if *format != "table" && *format != "json" {
+		fmt.Fprintf(os.Stderr, "llinter: unknown metrics format %q\n", *format)
+		return 2
+	}
+	if *level != "package" && *level != "group" {
+		fmt.Fprintf(os.Stderr, "llinter: unknown metrics level %q\n", *level)
+		return 2
+	}
+	patterns := fs.Args()
+	if len(patterns) == 0 {
+		patterns = []string{"./..."}
+	}
+
+	// 設定ファイルがなくても結合度は表示できる
+	cfg, err := config.LoadConfig(*configFile)
+	if err != nil && !config.IsNotFound(err) {
+		fmt.Fprintf(os.Stderr, "llinter: %v\n", err)
+		return 1
+	}
+	if *level == "group" && (cfg == nil || len(cfg.Groups) == 0) {
+		fmt.Fprintln(os.Stderr, "llinter: -level group requires groups in the configuration")
+		return 1
+	}
+
+	pkgs, err := packages.Load(&packages.Config{
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedImports | packages.NeedModule,
+	}, patterns...)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "llinter: %v\n", err)
+		return 1
+	}
+	if packages.PrintErrors(pkgs) > 0 {
+		return 1
+	}
+
+	metrics := computeMetrics(cfg, pkgs, *level == "group")
+	var thresholds *config.Metrics
+	if cfg != nil {
+		thresholds = cfg.Metrics
+	}
+	violations := checkMetrics(metrics, thresholds, *level)
+
+	if *format == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(struct {
+			Level      string             `json:"level"`
+			Metrics    []unitMetrics      `json:"metrics"`
+			Violations []metricsViolation `json:"violations,omitempty"`
+		}{*level, metrics, violations})
+	} else {
+		err = printMetrics(os.Stdout, metrics, *level)
+		for _, v := range violations {
+			fmt.Fprintf(os.Stderr, "%s: %s\n", v.Position, v.Message)
+		}
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "llinter: %v\n", err)
+		return 1
+	}
+
+	if len(violations) > 0 {
+		return 1
+	}
+	return 0
+}
+
+// computeMetrics はパッケージのimportから結合度を求める
+// groupsがtrueなら設定のグループ単位にまとめ、グループ内のimportは数えない
+func computeMetrics(cfg *config.Config, pkgs []*packages.Package, groups bool) []unitMetrics {
+	unitOf := func(pkgPath string) (string, bool) {
+		if !groups {
+			return pkgPath, true
+		}
+		if group := config.FindGroup(cfg, pkgPath); group != nil {
+			return group.Name, true
+		}
+		return "", false
+	}
+
+	type unit struct {
+		afferent, efferent, std, internal, thirdParty map[string]bool
+		pos                                           string
+	}
+	units := make(map[string]*unit)
+	get := func(name string) *unit {
+		u := units[name]
+		if u == nil {
+			u = &unit{
+				afferent:   make(map[string]bool),
+				efferent:   make(map[string]bool),
+				std:        make(map[string]bool),
+				internal:   make(map[string]bool),
+				thirdParty: make(map[string]bool),
+			}
+			units[name] = u
+		}
+		return u
+	}
+
+	// 位置が決定的になるようにパッケージの順に処理する
+	sorted := append([]*packages.Package{}, pkgs...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].PkgPath < sorted[j].PkgPath })
+
+	for _, pkg := range sorted {
+		name, ok := unitOf(pkg.PkgPath)
+		if !ok {
+			continue
+		}
+		u := get(name)
+		if u.pos == "" && len(pkg.GoFiles) > 0 {
+			u.pos = packageClausePos(pkg.GoFiles[0])
+		}
+
+		for importPath := range pkg.Imports {
+			if importPath == "C" {
+				continue
+			}
+			if to, ok := unitOf(importPath); ok && to == name {
+				continue
+			}
+
+			switch policy.ClassifyImport(importPath, modulePath(pkg)) {
+			case policy.ImportInternal:
+				u.internal[importPath] = true
+				u.efferent[importPath] = true
+			case policy.ImportStd:
+				u.std[importPath] = true
+			default:
+				u.thirdParty[importPath] = true
+				u.efferent[importPath] = true
+			}
+
+			if to, ok := unitOf(importPath); ok {
+				get(to).afferent[pkg.PkgPath] = true
+			}
+		}
+	}
+
+	metrics := make([]unitMetrics, 0, len(units))
+	for name, u := range units {
+		// 解析したパッケージから依存されているだけのパッケージは対象外
+		if u.pos == "" {
+			continue
+		}
+		m := unitMetrics{
+			Name:       name,
+			Afferent:   len(u.afferent),
+			Efferent:   len(u.efferent),
+			Std:        len(u.std),
+			Internal:   len(u.internal),
+			ThirdParty: len(u.thirdParty),
+			pos:        u.pos,
+		}
+		if total := m.Afferent + m.Efferent; total > 0 {
+			m.Instability = float64(m.Efferent) / float64(total)
+		}
+		metrics = append(metrics, m)
+	}
+	sort.Slice(metrics, func(i, j int) bool { return metrics[i].Name < metrics[j].Name })
+	return metrics
+}
+
+// modulePath はパッケージが属するモジュールのパスを返す
+func modulePath(pkg *packages.Package) string {
+	if pkg.Module == nil {
+		return ""
+	}
+	return pkg.Module.Path
+}
+
+// packageClausePos はファイルのpackage句の位置を返す
+func packageClausePos(filename string) string {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, filename, nil, parser.PackageClauseOnly)
+	if err != nil {
+		return filename
+	}
+	return fset.Position(f.Package).String()
+}
+
+// checkMetrics は結合度が閾値を超えていないか確認する
+func checkMetrics(metrics []unitMetrics, thresholds *config.Metrics, level string) []metricsViolation {
+	if thresholds == nil {
+		return nil
+	}
+
+	var violations []metricsViolation
+	for _, m := range metrics {
+		report := func(format string, args ...any) {
+			violations = append(violations, metricsViolation{
+				Name:     m.Name,
+				Position: m.pos,
+				Message:  fmt.Sprintf("%s %s: ", level, m.Name) + fmt.Sprintf(format, args...),
+			})
+		}
+
+		if t := thresholds.MaxAfferent; t > 0 && m.Afferent > t {
+			report("afferent coupling %d exceeds max_afferent %d", m.Afferent, t)
+		}
+		if t := thresholds.MaxEfferent; t > 0 && m.Efferent > t {
+			report("efferent coupling %d exceeds max_efferent %d", m.Efferent, t)
+		}
+		if t := thresholds.MaxInstability; t > 0 && m.Instability > t {
+			report("instability %.2f exceeds max_instability %.2f", m.Instability, t)
+		}
+		if t := thresholds.MaxThirdParty; t > 0 && m.ThirdParty > t {
+			report("third-party imports %d exceed max_third_party %d", m.ThirdParty, t)
+		}
+	}
+	return violations
+}
+
+// printMetrics は結合度を表形式で表示する
+func printMetrics(w io.Writer, metrics []unitMetrics, level string) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "%s\tCA\tCE\tINSTABILITY\tSTD\tINTERNAL\tTHIRD-PARTY\n", strings.ToUpper(level))
+	for _, m := range metrics {
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%.2f\t%d\t%d\t%d\n", m.Name, m.Afferent, m.Efferent, m.Instability, m.Std, m.Internal, m.ThirdParty)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/blck-snwmn/dependencylintgo/analyzer/config"
+)
+
+func TestComputeMetrics(t *testing.T) {
+	_, pkgs := loadTestModule(t, map[string]string{
+		"go.mod":     "module example.com/app\n\ngo 1.24\n\nrequire example.org/lib v0.0.0\n\nreplace example.org/lib => ./lib\n",
+		"lib/go.mod": "module example.org/lib\n\ngo 1.24\n",
+		"lib/lib.go": "package lib\n",
+		"api/api.go": "package api\n\nimport (\n\t_ \"example.com/app/db\"\n\t_ \"example.com/app/model\"\n\t_ \"example.org/lib\"\n\t_ \"fmt\"\n)\n",
+		"db/db.go":   "package db\n\nimport (\n\t_ \"database/sql\"\n\t_ \"example.com/app/model\"\n)\n",
+		"model/m.go": "// Package model はモデルだ\npackage model\n",
+	})
+	cfg := &config.Config{
+		Groups: []config.Group{
+			{Name: "web", Packages: []string{"example.com/app/api"}},
+			{Name: "storage", Packages: []string{"example.com/app/db", "example.com/app/model"}},
+		},
+		Metrics: &config.Metrics{MaxEfferent: 2, MaxInstability: 0.9},
+	}
+
+	t.Run("パッケージ単位", func(t *testing.T) {
+		metrics := computeMetrics(cfg, pkgs, false)
+		want := []unitMetrics{
+			{Name: "example.com/app/api", Afferent: 0, Efferent: 3, Instability: 1, Std: 1, Internal: 2, ThirdParty: 1},
+			{Name: "example.com/app/db", Afferent: 1, Efferent: 1, Instability: 0.5, Std: 1, Internal: 1},
+			{Name: "example.com/app/model", Afferent: 2, Efferent: 0, Instability: 0},
+		}
+		if len(metrics) != len(want) {
+			t.Fatalf("Expected %d packages, got %+v", len(want), metrics)
+		}
+		for i := range want {
+			got := metrics[i]
+			got.pos = ""
+			if got != want[i] {
+				t.Errorf("metrics[%d] = %+v, want %+v", i, got, want[i])
+			}
+		}
+		if !strings.HasSuffix(metrics[2].pos, "m.go:2:1") {
+			t.Errorf("Expected position of the package clause, got %q", metrics[2].pos)
+		}
+
+		violations := checkMetrics(metrics, cfg.Metrics, "package")
+		var messages []string
+		for _, v := range violations {
+			messages = append(messages, v.Message)
+		}
+		wantMessages := []string{
+			"package example.com/app/api: efferent coupling 3 exceeds max_efferent 2",
+			"package example.com/app/api: instability 1.00 exceeds max_instability 0.90",
+		}
+		if strings.Join(messages, "\n") != strings.Join(wantMessages, "\n") {
+			t.Errorf("checkMetrics() = %v, want %v", messages, wantMessages)
+		}
+
+		var buf bytes.Buffer
+		if err := printMetrics(&buf, metrics, "package"); err != nil {
+			t.Fatal(err)
+		}
+		if !strings.HasPrefix(buf.String(), "PACKAGE ") || !strings.Contains(buf.String(), "example.com/app/db") {
+			t.Errorf("Unexpected table:\n%s", buf.String())
+		}
+	})
+
+	t.Run("グループ単位", func(t *testing.T) {
+		metrics := computeMetrics(cfg, pkgs, true)
+		want := []unitMetrics{
+			{Name: "storage", Afferent: 1, Efferent: 0, Instability: 0, Std: 1},
+			{Name: "web", Afferent: 0, Efferent: 3, Instability: 1, Std: 1, Internal: 2, ThirdParty: 1},
+		}
+		if len(metrics) != len(want) {
+			t.Fatalf("Expected %d groups, got %+v", len(want), metrics)
+		}
+		for i := range want {
+			got := metrics[i]
+			got.pos = ""
+			if got != want[i] {
+				t.Errorf("metrics[%d] = %+v, want %+v", i, got, want[i])
+			}
+		}
+	})
+}
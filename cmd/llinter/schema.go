@@ -0,0 +1,13 @@
+package main
+
+import (
+	"os"
+
+	"github.com/blck-snwmn/dependencylintgo/analyzer/config"
+)
+
+// runSchema は設定ファイルのJSON Schemaを出力する
+func runSchema(args []string) int {
+	os.Stdout.Write(config.JSONSchema)
+	return 0
+}
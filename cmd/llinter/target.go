@@ -0,0 +1,106 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// target は解析するビルド構成だ
+type target struct {
+	GOOS   string
+	GOARCH string
+	Tags   []string
+}
+
+func (t target) String() string {
+	s := t.GOOS
+	if t.GOARCH != "" {
+		s += "/" + t.GOARCH
+	}
+	if len(t.Tags) > 0 {
+		s += ":" + strings.Join(t.Tags, ",")
+	}
+	return s
+}
+
+// env はターゲット向けに実行するときの環境変数を返す
+func (t target) env(base []string) []string {
+	env := append([]string{}, base...)
+	env = append(env, "GOOS="+t.GOOS)
+	if t.GOARCH != "" {
+		env = append(env, "GOARCH="+t.GOARCH)
+	}
+	if len(t.Tags) > 0 {
+		goflags := strings.TrimSpace(os.Getenv("GOFLAGS") + " -tags=" + strings.Join(t.Tags, ","))
+		env = append(env, "GOFLAGS="+goflags)
+	}
+	return env
+}
+
+// parseTarget は GOOS[/GOARCH][:tag,...] 形式のターゲットを解析する
+func parseTarget(s string) (target, error) {
+	var t target
+	platform, tags, hasTags := strings.Cut(s, ":")
+	if hasTags {
+		for _, tag := range strings.Split(tags, ",") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				t.Tags = append(t.Tags, tag)
+			}
+		}
+	}
+
+	t.GOOS, t.GOARCH, _ = strings.Cut(platform, "/")
+	if t.GOOS == "" {
+		return target{}, fmt.Errorf("invalid target %q: GOOS is required", s)
+	}
+	return t, nil
+}
+
+// targetList は-targetフラグの値だ（ヘルプ表示用）
+type targetList []target
+
+func (l *targetList) String() string { return "" }
+
+func (l *targetList) Set(value string) error {
+	t, err := parseTarget(value)
+	if err != nil {
+		return err
+	}
+	*l = append(*l, t)
+	return nil
+}
+
+// runTargets はターゲットごとにanalyzerを実行し、最も大きい終了コードを返す
+// ビルド制約の判定（go/buildのbuild.Default）にもGOOS/GOARCHを反映するため、ターゲットごとに自身を実行し直す
+func runTargets(specs []string, args []string) int {
+	exe, err := os.Executable()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "llinter: %v\n", err)
+		return 1
+	}
+
+	exitCode := 0
+	for _, spec := range specs {
+		t, err := parseTarget(spec)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "llinter: %v\n", err)
+			return 1
+		}
+
+		fmt.Fprintf(os.Stderr, "# target %s\n", t)
+		cmd := exec.Command(exe, args...)
+		cmd.Env = t.env(os.Environ())
+		cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+		if err := cmd.Run(); err != nil {
+			exitErr, ok := err.(*exec.ExitError)
+			if !ok {
+				fmt.Fprintf(os.Stderr, "llinter: %v\n", err)
+				return 1
+			}
+			exitCode = max(exitCode, exitErr.ExitCode())
+		}
+	}
+	return exitCode
+}
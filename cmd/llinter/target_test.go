@@ -0,0 +1,63 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseTarget(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    target
+		wantErr bool
+	}{
+		{
+			name:  "GOOSのみ",
+			input: "windows",
+			want:  target{GOOS: "windows"},
+		},
+		{
+			name:  "GOOSとGOARCH",
+			input: "linux/arm64",
+			want:  target{GOOS: "linux", GOARCH: "arm64"},
+		},
+		{
+			name:  "タグ付き",
+			input: "linux/amd64:integration,e2e",
+			want:  target{GOOS: "linux", GOARCH: "amd64", Tags: []string{"integration", "e2e"}},
+		},
+		{
+			name:    "GOOSなし",
+			input:   ":integration",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseTarget(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseTarget() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseTarget() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractTargets(t *testing.T) {
+	args := []string{"-config", ".llinter.yaml", "-target", "windows", "--target=linux/amd64:integration", "./..."}
+
+	rest, targets := extractFlag(args, "target")
+
+	wantRest := []string{"-config", ".llinter.yaml", "./..."}
+	if !reflect.DeepEqual(rest, wantRest) {
+		t.Errorf("rest = %v, want %v", rest, wantRest)
+	}
+	wantTargets := []string{"windows", "linux/amd64:integration"}
+	if !reflect.DeepEqual(targets, wantTargets) {
+		t.Errorf("targets = %v, want %v", targets, wantTargets)
+	}
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/blck-snwmn/dependencylintgo/analyzer/config"
+	"github.com/blck-snwmn/dependencylintgo/analyzer/policy"
+	"golang.org/x/tools/go/packages"
+)
+
+// reportUnusedRules はすべてのパッケージを読み込み、どのファイルやimportにも使われなかったルールとパターンを表示する
+// 使われていないものがあれば1を返す
+func reportUnusedRules(args []string) int {
+	fs := flag.NewFlagSet("report-unused-rules", flag.ContinueOnError)
+	configFile := fs.String("config", config.DefaultConfigName, "configuration file path")
+	hierarchical := fs.Bool("hierarchical", false, "not supported with -report-unused-rules")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if *hierarchical {
+		fmt.Fprintln(os.Stderr, "llinter: -report-unused-rules does not support -hierarchical")
+		return 2
+	}
+	patterns := fs.Args()
+	if len(patterns) == 0 {
+		patterns = []string{"./..."}
+	}
+
+	cfg, err := config.LoadConfig(*configFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "llinter: %v\n", err)
+		return 1
+	}
+
+	pkgs, err := loadSourcePackages(patterns, true)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "llinter: %v\n", err)
+		return 1
+	}
+	if packages.PrintErrors(pkgs) > 0 {
+		return 1
+	}
+
+	usage, err := collectUsage(policy.New(cfg), pkgs)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "llinter: %v\n", err)
+		return 1
+	}
+
+	unused, exceptions := usage.Unused(), usage.UnusedExceptions()
+	printUnused(os.Stdout, unused, exceptions)
+	if len(unused) > 0 || len(exceptions) > 0 {
+		return 1
+	}
+	return 0
+}
+
+// collectUsage はパッケージのすべてのファイルについて、importcheckと同じ方法でルールとパターンの使用状況を集計する
+func collectUsage(pol *policy.Policy, pkgs []*packages.Package) (*policy.Usage, error) {
+	files, err := sourceFiles(pkgs)
+	if err != nil {
+		return nil, err
+	}
+
+	usage := pol.NewUsage()
+	for _, f := range files {
+		usage.AddFile(f.file, f.pkg.PkgPath, f.imports)
+	}
+	return usage, nil
+}
+
+// printUnused は使われなかったルールとパターン、例外を表示する
+func printUnused(w io.Writer, unused []policy.UnusedRule, exceptions []policy.UnusedException) {
+	for _, r := range unused {
+		if r.NoFile {
+			fmt.Fprintf(w, "rules[%d]: path %q matched no file\n", r.Index, r.Rule.Path)
+			continue
+		}
+		for _, pattern := range r.Paths {
+			fmt.Fprintf(w, "rules[%d]: path %q matched no file\n", r.Index, pattern)
+		}
+		for _, pattern := range r.Deny {
+			fmt.Fprintf(w, "rules[%d]: deny %q matched no import\n", r.Index, pattern)
+		}
+		for _, pattern := range r.Allow {
+			fmt.Fprintf(w, "rules[%d]: allow %q never overrode a deny\n", r.Index, pattern)
+		}
+	}
+	for _, e := range exceptions {
+		fmt.Fprintf(w, "exceptions[%d]: import %q matched no denied import\n", e.Index, e.Config.Import)
+	}
+}
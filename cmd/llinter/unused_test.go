@@ -0,0 +1,80 @@
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/blck-snwmn/dependencylintgo/analyzer/config"
+	"github.com/blck-snwmn/dependencylintgo/analyzer/policy"
+)
+
+func TestCollectUsage(t *testing.T) {
+	pkgs := loadSourceTestModule(t, map[string]string{
+		"go.mod":                   "module example.com/app\n\ngo 1.24\n",
+		"internal/api/api.go":      "package api\n\nimport \"strings\"\n\nvar _ = strings.ToUpper\n",
+		"internal/api/api_test.go": "package api\n\nimport (\n\t\"os\"\n\t\"testing\"\n)\n\nfunc TestAPI(t *testing.T) { _ = os.Args }\n",
+	}, true)
+
+	pol := policy.New(&config.Config{
+		Rules: []config.Rule{
+			{
+				Path:  []string{"internal/**"},
+				Deny:  []string{"**", "net/**"},
+				Allow: []string{"strings", "testing", "fmt"},
+			},
+			{
+				Path: []string{"cmd/**"},
+				Deny: []string{"os"},
+			},
+		},
+		Exceptions: []config.Exception{
+			{Path: []string{"internal/api/api_test.go"}, Import: "os", Owner: "@team-a"},
+			{Packages: []string{"example.com/app/internal/api"}, Import: "net/http", Owner: "@team-a"},
+		},
+	})
+	usage, err := collectUsage(pol, pkgs)
+	if err != nil {
+		t.Fatalf("collectUsage() error = %v", err)
+	}
+
+	var buf bytes.Buffer
+	printUnused(&buf, usage.Unused(), usage.UnusedExceptions())
+	want := "rules[0]: deny \"net/**\" matched no import\n" +
+		"rules[0]: allow \"fmt\" never overrode a deny\n" +
+		"rules[1]: path [\"cmd/**\"] matched no file\n" +
+		"exceptions[1]: import \"net/http\" matched no denied import\n"
+	if buf.String() != want {
+		t.Errorf("printUnused() =\n%s\nwant\n%s", buf.String(), want)
+	}
+}
+
+func TestReportUnusedRules(t *testing.T) {
+	t.Chdir(writeTestModule(t, map[string]string{
+		"go.mod":              "module example.com/app\n\ngo 1.24\n",
+		"internal/api/api.go": "package api\n\nimport \"strings\"\n\nvar _ = strings.ToUpper\n",
+		".llinter.yaml": `rules:
+  - path: ["internal/**"]
+    deny: ["strings"]
+`,
+	}))
+
+	// ファイルはモジュールルートからの相対パスで照合するので、すべてのルールが使われる
+	if code := reportUnusedRules([]string{"./..."}); code != 0 {
+		t.Errorf("reportUnusedRules() = %d, want 0", code)
+	}
+}
+
+func TestExtractBoolFlag(t *testing.T) {
+	rest, ok := extractBoolFlag([]string{"-config", "x.yaml", "--report-unused-rules", "./..."}, "report-unused-rules")
+	if !ok {
+		t.Error("Expected flag to be enabled")
+	}
+	if want := []string{"-config", "x.yaml", "./..."}; !reflect.DeepEqual(rest, want) {
+		t.Errorf("Expected rest %v, got %v", want, rest)
+	}
+
+	if _, ok := extractBoolFlag([]string{"-report-unused-rules=false", "report-unused-rules"}, "report-unused-rules"); ok {
+		t.Error("Expected flag to be disabled")
+	}
+}
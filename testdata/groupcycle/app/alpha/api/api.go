@@ -0,0 +1,10 @@
+package api
+
+import (
+	"example.com/app/beta/core" // want "import \"example.com/app/beta/core\" creates a cycle between groups: alpha -> beta -> alpha \\(example.com/app/alpha/api -> example.com/app/beta/core, example.com/app/beta/other -> example.com/app/alpha/model\\)"
+)
+
+// Name はbetaのコアの名前を返す
+func Name() string {
+	return core.Name
+}
@@ -0,0 +1,4 @@
+package model
+
+// Name はalphaのモデルの名前だ
+const Name = "alpha"
@@ -0,0 +1,4 @@
+package core
+
+// Name はbetaのコアの名前だ
+const Name = "beta"
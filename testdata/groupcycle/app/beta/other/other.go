@@ -0,0 +1,10 @@
+package other
+
+import (
+	"example.com/app/alpha/model" // want "import \"example.com/app/alpha/model\" creates a cycle between groups: beta -> alpha -> beta \\(example.com/app/beta/other -> example.com/app/alpha/model, example.com/app/alpha/api -> example.com/app/beta/core\\)"
+)
+
+// Name はalphaのモデルの名前を返す
+func Name() string {
+	return model.Name
+}
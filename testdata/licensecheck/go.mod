@@ -0,0 +1,17 @@
+module example.com/licensecheck
+
+go 1.24
+
+require (
+	example.org/gpl v1.0.0
+	example.org/mit v1.0.0
+	example.org/nolicense v1.0.0
+	example.org/odd v1.0.0
+)
+
+replace (
+	example.org/gpl => ./third_party/gpl
+	example.org/mit => ./third_party/mit
+	example.org/nolicense => ./third_party/nolicense
+	example.org/odd => ./third_party/odd
+)
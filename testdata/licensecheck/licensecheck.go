@@ -0,0 +1,10 @@
+package licensecheck
+
+import (
+	_ "example.org/gpl" // want `import "example.org/gpl" uses module example.org/gpl@v1.0.0 licensed under GPL-3.0, which is not allowed based on configuration`
+	_ "example.org/mit"
+	_ "example.org/mit/sub"
+	_ "example.org/nolicense" // want `import "example.org/nolicense" uses module example.org/nolicense@v1.0.0 with unknown license \(no license file\), which is not allowed based on configuration`
+	_ "example.org/odd"       // want `import "example.org/odd" uses module example.org/odd@v1.0.0 with unknown license \(unrecognized license file LICENSE.txt\), which is not allowed based on configuration`
+	_ "fmt"
+)
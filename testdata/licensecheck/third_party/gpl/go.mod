@@ -0,0 +1,3 @@
+module example.org/gpl
+
+go 1.24
@@ -0,0 +1,3 @@
+module example.org/mit
+
+go 1.24
@@ -0,0 +1,3 @@
+module example.org/nolicense
+
+go 1.24
@@ -0,0 +1,21 @@
+module example.com/modcheck
+
+go 1.24
+
+require (
+	example.org/banned v1.0.0
+	example.org/ok v1.0.0
+	example.org/old v1.2.3
+)
+
+replace example.org/banned => ./third_party/banned
+
+replace (
+	example.org/ok => ./third_party/ok
+	example.org/old => ./third_party/old
+)
+
+tool (
+	example.org/banned/cmd/x
+	example.org/ok/cmd/gen
+)
@@ -0,0 +1,3 @@
+package modcheck
+
+import _ "example.org/old" // want `import "example.org/old" uses module example.org/old@v1.2.3, which does not satisfy version constraint ">= v1.5.0" based on configuration`
@@ -0,0 +1,3 @@
+module example.org/banned
+
+go 1.24
@@ -0,0 +1,3 @@
+module example.org/ok
+
+go 1.24
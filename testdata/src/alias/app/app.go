@@ -0,0 +1,13 @@
+package app
+
+import (
+	"alias/client" // want "import \"alias/client\" must use alias \"clientv2\" based on configuration"
+	_ "alias/driver"
+	_ "alias/other" // want "blank import of \"alias/other\" is not allowed in this file based on configuration"
+	. "strings"     // want "dot import of \"strings\" is not allowed in this file based on configuration"
+)
+
+// Run はクライアントを使う
+func Run() string {
+	return client.New() + ToUpper(client.New())
+}
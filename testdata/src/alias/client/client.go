@@ -0,0 +1,6 @@
+package client
+
+// New はクライアントを作る
+func New() string {
+	return "client"
+}
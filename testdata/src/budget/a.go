@@ -0,0 +1,16 @@
+package budget // want `file imports 5 packages, exceeding max_imports 3 based on configuration` `file imports 2 third-party packages, exceeding max_third_party_imports 1 based on configuration` `package imports 6 packages, exceeding max_package_imports 4 based on configuration` `package imports 3 third-party packages, exceeding max_package_third_party_imports 2 based on configuration`
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"thirdparty.example/one"
+	"thirdparty.example/two"
+)
+
+func A() {
+	fmt.Println(strings.ToUpper(os.Args[0]))
+	one.Use()
+	two.Use()
+}
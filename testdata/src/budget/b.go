@@ -0,0 +1,12 @@
+package budget
+
+import (
+	"fmt"
+
+	"thirdparty.example/three"
+)
+
+func B() {
+	fmt.Println("b")
+	three.Use()
+}
@@ -0,0 +1,20 @@
+package budget // want `file imports 5 packages, exceeding max_imports 3 based on configuration` `file imports 2 third-party packages, exceeding max_third_party_imports 1 based on configuration`
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"thirdparty.example/one"
+	"thirdparty.example/two"
+)
+
+// テストファイルにもファイル単位の上限は適用するが、
+// importはtests: onlyでないルールのパッケージ単位の上限には数えない
+func TestA(t *testing.T) {
+	_ = io.EOF
+	_ = http.MethodGet
+	one.Use()
+	two.Use()
+	A()
+}
@@ -0,0 +1,11 @@
+package budgettests
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+func Lib() {
+	fmt.Println(strings.ToUpper(os.Args[0]))
+}
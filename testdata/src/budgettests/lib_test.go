@@ -0,0 +1,11 @@
+package budgettests // want `package imports 3 packages, exceeding max_package_imports 2 based on configuration`
+
+import (
+	"io"
+	"testing"
+)
+
+func TestLib(t *testing.T) {
+	_ = io.EOF
+	Lib()
+}
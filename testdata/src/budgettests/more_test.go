@@ -0,0 +1,10 @@
+package budgettests
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMore(t *testing.T) {
+	_ = errors.New("more")
+}
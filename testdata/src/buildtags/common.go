@@ -0,0 +1,10 @@
+package buildtags
+
+import (
+	"os" // want "import \"os\" is not allowed in this file based on configuration"
+)
+
+// Getenv はどのOSでもビルドされる
+func Getenv() string {
+	return os.Getenv("HOME")
+}
@@ -0,0 +1,12 @@
+//go:build !plan9
+
+package buildtags
+
+import (
+	"os"
+)
+
+// Hostname はplan9向けのビルドには含まれない
+func Hostname() (string, error) {
+	return os.Hostname()
+}
@@ -0,0 +1,6 @@
+package core
+
+// Account はアカウントを表す
+type Account struct {
+	ID string
+}
@@ -0,0 +1,10 @@
+package other
+
+import (
+	"cycle/payments/model" // want "import \"cycle/payments/model\" creates a cycle between groups: accounts -> payments -> accounts \\(cycle/accounts/other -> cycle/payments/model, cycle/payments/api -> cycle/accounts/core\\)"
+)
+
+// Payments はアカウントの支払いを返す
+func Payments(id string) []model.Payment {
+	return []model.Payment{{AccountID: id}}
+}
@@ -0,0 +1,11 @@
+package api
+
+import (
+	"cycle/billing/model"
+	"cycle/users/core" // want "import \"cycle/users/core\" creates a cycle between groups: billing -> users -> billing \\(cycle/billing/api -> cycle/users/core, cycle/users/core -> cycle/billing/model\\)"
+)
+
+// Handler は請求APIのハンドラ
+func Handler(u core.User) []model.Invoice {
+	return u.Invoices
+}
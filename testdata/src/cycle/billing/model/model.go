@@ -0,0 +1,6 @@
+package model
+
+// Invoice は請求情報を表す
+type Invoice struct {
+	UserID string
+}
@@ -0,0 +1,11 @@
+package api
+
+import (
+	"cycle/orders/model"
+	"cycle/shared/bridge" // want "import \"cycle/shared/bridge\" creates a cycle between groups: orders -> shipping -> orders \\(cycle/orders/api -> cycle/shared/bridge -> cycle/shipping/core, cycle/shipping/core -> cycle/orders/model\\)"
+)
+
+// Status は注文の配送状況を返す
+func Status(o model.Order) string {
+	return bridge.Track(o)
+}
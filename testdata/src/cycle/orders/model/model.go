@@ -0,0 +1,6 @@
+package model
+
+// Order は注文を表す
+type Order struct {
+	ID string
+}
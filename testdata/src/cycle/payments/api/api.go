@@ -0,0 +1,11 @@
+package api
+
+import (
+	"cycle/accounts/core" // want "import \"cycle/accounts/core\" creates a cycle between groups: payments -> accounts -> payments \\(cycle/payments/api -> cycle/accounts/core, cycle/accounts/other -> cycle/payments/model\\)"
+)
+
+// Charge はアカウントに請求する
+// paymentsとaccountsの循環は、互いにimportしない別々のパッケージの組で生まれる
+func Charge(a core.Account) string {
+	return a.ID
+}
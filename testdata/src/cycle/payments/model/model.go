@@ -0,0 +1,6 @@
+package model
+
+// Payment は支払いを表す
+type Payment struct {
+	AccountID string
+}
@@ -0,0 +1,12 @@
+package bridge
+
+import (
+	"cycle/orders/model"
+	"cycle/shipping/core"
+)
+
+// Track は注文の配送を追跡する
+// このパッケージはどのグループにも属さず、ordersからshippingへの依存を仲介する
+func Track(o model.Order) string {
+	return core.Shipment{Order: o}.Order.ID
+}
@@ -0,0 +1,10 @@
+package core
+
+import (
+	"cycle/orders/model" // want "import \"cycle/orders/model\" creates a cycle between groups: shipping -> orders -> shipping \\(cycle/shipping/core -> cycle/orders/model, cycle/orders/api -> cycle/shared/bridge -> cycle/shipping/core\\)"
+)
+
+// Shipment は注文の配送を表す
+type Shipment struct {
+	Order model.Order
+}
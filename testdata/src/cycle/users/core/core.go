@@ -0,0 +1,11 @@
+package core
+
+import (
+	"cycle/billing/model" // want "import \"cycle/billing/model\" creates a cycle between groups: users -> billing -> users \\(cycle/users/core -> cycle/billing/model, cycle/billing/api -> cycle/users/core\\)"
+)
+
+// User はユーザー情報を表す
+type User struct {
+	ID       string
+	Invoices []model.Invoice
+}
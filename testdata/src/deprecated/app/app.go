@@ -0,0 +1,6 @@
+package app
+
+import (
+	_ "deprecated/oldhttp/client" // want `import "deprecated/oldhttp/client" was deprecated until 2000-01-01 and is no longer allowed based on configuration`
+	_ "deprecated/oldlog"         // want `\[warning\] import "deprecated/oldlog" is deprecated and will not be allowed after 2999-12-31 based on configuration; use "log/slog" instead`
+)
@@ -0,0 +1,7 @@
+package exceptions
+
+import (
+	_ "fmt" // want `import "fmt" is not allowed in this file based on configuration`
+	_ "os"
+	_ "strings" // want `import "strings" is not allowed in this file based on configuration` `exception for import "strings" expired on 2000-01-01 \(owner: @team-b, ticket: APP-2\) based on configuration`
+)
@@ -0,0 +1,3 @@
+package exceptions
+
+import _ "os" // want `import "os" is not allowed in this file based on configuration`
@@ -0,0 +1,10 @@
+package generated
+
+import (
+	"os" // want "import \"os\" is not allowed in this file based on configuration"
+)
+
+// Exit は手書きのコード
+func Exit() {
+	os.Exit(0)
+}
@@ -0,0 +1,11 @@
+package hier
+
+import (
+	"os" // want "import \"os\" is not allowed in this file based on configuration"
+	"strings"
+)
+
+// Home はルートの設定が適用される
+func Home() string {
+	return strings.ToUpper(os.Getenv("HOME"))
+}
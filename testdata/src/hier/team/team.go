@@ -0,0 +1,11 @@
+package team
+
+import (
+	"os"      // want "import \"os\" is not allowed in this file based on configuration"
+	"strings" // want "import \"strings\" is not allowed in this file based on configuration"
+)
+
+// Home はサブディレクトリの設定が優先され、上位の設定のdenyも引き継ぐ
+func Home() string {
+	return strings.ToUpper(os.Getenv("HOME"))
+}
@@ -0,0 +1,9 @@
+package severity
+
+import (
+	"os" // want `\[warning\] import "os" is not allowed in this file based on configuration`
+)
+
+func Getenv() string {
+	return os.Getenv("HOME")
+}
@@ -0,0 +1,10 @@
+package symbols
+
+import (
+	f "fmt"
+)
+
+// AliasedPrint は別名importで禁止されたシンボルを使う
+func AliasedPrint() {
+	f.Println("aliased") // want "use of fmt.Println is not allowed in this file based on configuration"
+}
@@ -0,0 +1,10 @@
+package symbols
+
+import (
+	. "time"
+)
+
+// DotNow はドットimportで禁止されたシンボルを使う
+func DotNow() Time {
+	return Now() // want "use of time.Now is not allowed in this file based on configuration"
+}
@@ -0,0 +1,16 @@
+package symbols
+
+import (
+	"fmt"
+	"time"
+)
+
+// Format はfmt.Sprintfを使うので許可される
+func Format(d time.Duration) string {
+	return fmt.Sprintf("%s", d)
+}
+
+// Print は禁止されたシンボルを使う
+func Print() {
+	fmt.Println(time.Now()) // want "use of fmt.Println is not allowed in this file based on configuration" "use of time.Now is not allowed in this file based on configuration"
+}
@@ -0,0 +1,13 @@
+package testaware_test
+
+import (
+	"net/http/httptest"
+	"os"
+	"strings" // want "import \"strings\" is not allowed in this file based on configuration"
+	"testing"
+)
+
+func TestExternal(t *testing.T) {
+	_ = httptest.NewRecorder()
+	_ = strings.ToUpper(os.Getenv("HOME"))
+}
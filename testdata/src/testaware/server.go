@@ -0,0 +1,10 @@
+package testaware
+
+import (
+	"net/http/httptest" // want "import \"net/http/httptest\" is not allowed in this file based on configuration"
+)
+
+// NewRecorder は本番コードからhttptestを使う
+func NewRecorder() *httptest.ResponseRecorder {
+	return httptest.NewRecorder()
+}
@@ -0,0 +1,12 @@
+package testaware
+
+import (
+	"net/http/httptest"
+	"os" // want "import \"os\" is not allowed in this file based on configuration"
+	"testing"
+)
+
+func TestRecorder(t *testing.T) {
+	_ = httptest.NewRecorder()
+	_ = os.Getenv("HOME")
+}
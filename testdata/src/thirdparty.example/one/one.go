@@ -0,0 +1,3 @@
+package one
+
+func Use() {}
@@ -0,0 +1,6 @@
+package internal
+
+import (
+	_ "os" // want `import "os" is not allowed in this file based on configuration`
+	_ "strings"
+)
@@ -0,0 +1,6 @@
+package internal
+
+import (
+	_ "os"
+	_ "strings" // want `import "strings" is not allowed in this file based on configuration`
+)